@@ -0,0 +1,43 @@
+package idx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"strconv"
+)
+
+// Args returns ids as a slice of query arguments, for database/sql calls and for sqlx.In, which expands
+// []ID arguments on its own:
+//
+//	query, args, err := sqlx.In("SELECT * FROM orders WHERE id IN (?)", ids)
+//	rows, err := db.Query("SELECT * FROM orders WHERE id IN (?, ?)", idx.Args(ids)...)
+func Args(ids []ID) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return args
+}
+
+// Values returns the driver values of ids, as returned by Value, for bulk writers building multi-row
+// INSERTs. Value never fails for IDs, so there is no error to handle.
+func Values(ids []ID) []driver.Value {
+	values := make([]driver.Value, len(ids))
+	for i, id := range ids {
+		values[i], _ = id.Value()
+	}
+	return values
+}
+
+// NamedArgs returns ids as sql.NamedArg arguments named name0, name1 and so on, for drivers binding
+// named parameters:
+//
+//	db.Exec("INSERT INTO tags (id) VALUES (@id0), (@id1)", idx.NamedArgs("id", ids)...)
+func NamedArgs(name string, ids []ID) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		value, _ := id.Value()
+		args[i] = sql.Named(name+strconv.Itoa(i), value)
+	}
+	return args
+}
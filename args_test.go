@@ -0,0 +1,57 @@
+package idx
+
+import (
+	"bytes"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"strconv"
+	"testing"
+)
+
+func TestArgs(t *testing.T) {
+	ids := []ID{NewID(), NewID()}
+	args := Args(ids)
+	if len(args) != 2 || args[0] != ids[0] || args[1] != ids[1] {
+		t.Fatalf("Arguments %v did not match with %v", args, ids)
+	}
+	if args = Args(nil); len(args) != 0 {
+		t.Fatalf("Was expecting no arguments, got %v", args)
+	}
+}
+
+func TestArgs_SqlxIn(t *testing.T) {
+	ids := []ID{NewID(), NewID(), NewID()}
+	query, args, err := sqlx.In("SELECT * FROM orders WHERE id IN (?) AND owner_id = ?", ids, ids[0])
+	if err != nil {
+		t.Fatalf("Got error while expanding query %v", err)
+	}
+	if query != "SELECT * FROM orders WHERE id IN (?, ?, ?) AND owner_id = ?" {
+		t.Fatalf("Unexpected query %q", query)
+	}
+	// sqlx.In resolves single driver.Valuer arguments itself, so the last one is already the stored bytes.
+	if len(args) != 4 || args[0] != ids[0] || args[2] != ids[2] || !bytes.Equal(args[3].([]byte), ids[0][:]) {
+		t.Fatalf("Arguments %v did not match with %v", args, ids)
+	}
+	if query, args, err = sqlx.In("SELECT * FROM orders WHERE id IN (?)", Args(ids)); err != nil || len(args) != 3 {
+		t.Fatalf("Unexpected expansion %q %v: %v", query, args, err)
+	}
+}
+
+func TestValues(t *testing.T) {
+	ids := []ID{NewID(), NilID}
+	values := Values(ids)
+	if len(values) != 2 || !bytes.Equal(values[0].([]byte), ids[0][:]) || values[1] != nil {
+		t.Fatalf("Values %v did not match with %v", values, ids)
+	}
+}
+
+func TestNamedArgs(t *testing.T) {
+	ids := []ID{NewID(), NewID()}
+	args := NamedArgs("id", ids)
+	for i, arg := range args {
+		named, ok := arg.(sql.NamedArg)
+		if !ok || named.Name != "id"+strconv.Itoa(i) || !bytes.Equal(named.Value.([]byte), ids[i][:]) {
+			t.Fatalf("Argument %v did not match with %s", arg, ids[i].String())
+		}
+	}
+}
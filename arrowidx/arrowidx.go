@@ -0,0 +1,89 @@
+// Package arrowidx stores idx.ID values in Apache Arrow FixedSizeBinary(16) arrays.
+package arrowidx
+
+import (
+	"errors"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/ieshan/idx"
+	"unsafe"
+)
+
+// ErrByteWidth is returned when an array does not have a byte width of 16.
+var ErrByteWidth = errors.New("arrowidx: array byte width is not 16")
+
+// DataType is the Arrow type of ID arrays.
+var DataType = &arrow.FixedSizeBinaryType{ByteWidth: len(idx.NilID)}
+
+// Builder builds ID arrays.
+type Builder struct {
+	*array.FixedSizeBinaryBuilder
+}
+
+// NewBuilder returns a Builder allocating from mem.
+func NewBuilder(mem memory.Allocator) *Builder {
+	return &Builder{array.NewFixedSizeBinaryBuilder(mem, DataType)}
+}
+
+// AppendID appends id to the array.
+func (b *Builder) AppendID(id idx.ID) {
+	b.Append(id[:])
+}
+
+// AppendIDs appends ids to the array. valid marks null entries when it is not nil, and must then have the
+// same length as ids.
+func (b *Builder) AppendIDs(ids []idx.ID, valid []bool) {
+	b.Reserve(len(ids))
+	for i := range ids {
+		if valid != nil && !valid[i] {
+			b.AppendNull()
+			continue
+		}
+		b.UnsafeAppend(ids[i][:])
+	}
+}
+
+// FromIDs returns an array backed directly by the memory of ids, without copying. The slice must not be
+// modified while the array is in use.
+func FromIDs(ids []idx.ID) *array.FixedSizeBinary {
+	var buf []byte
+	if len(ids) > 0 {
+		buf = unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(ids))), len(ids)*len(idx.NilID))
+	}
+	data := array.NewData(DataType, len(ids), []*memory.Buffer{nil, memory.NewBufferBytes(buf)}, nil, 0, 0)
+	defer data.Release()
+	return array.NewFixedSizeBinaryData(data)
+}
+
+// IDs returns the values of arr as a slice sharing its memory, without copying. Null entries hold whatever
+// bytes the array stores for them, usually NilID. The slice is only valid while arr is retained and must
+// not be modified.
+func IDs(arr *array.FixedSizeBinary) ([]idx.ID, error) {
+	if err := checkByteWidth(arr); err != nil {
+		return nil, err
+	}
+	if arr.Len() == 0 {
+		return nil, nil
+	}
+	first := arr.Value(0)
+	return unsafe.Slice((*idx.ID)(unsafe.Pointer(unsafe.SliceData(first))), arr.Len()), nil
+}
+
+// Value returns the ID at index i of arr. Null entries return idx.NilID.
+func Value(arr *array.FixedSizeBinary, i int) (idx.ID, error) {
+	if err := checkByteWidth(arr); err != nil {
+		return idx.NilID, err
+	}
+	if arr.IsNull(i) {
+		return idx.NilID, nil
+	}
+	return idx.ID(arr.Value(i)), nil
+}
+
+func checkByteWidth(arr *array.FixedSizeBinary) error {
+	if dt, ok := arr.DataType().(*arrow.FixedSizeBinaryType); !ok || dt.ByteWidth != len(idx.NilID) {
+		return ErrByteWidth
+	}
+	return nil
+}
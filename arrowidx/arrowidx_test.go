@@ -0,0 +1,78 @@
+package arrowidx
+
+import (
+	"errors"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/ieshan/idx"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	ids := []idx.ID{idx.NewID(), idx.NewID(), idx.NewID()}
+	b := NewBuilder(mem)
+	defer b.Release()
+	b.AppendID(ids[0])
+	b.AppendIDs(ids[1:], []bool{false, true})
+	arr := b.NewFixedSizeBinaryArray()
+	defer arr.Release()
+
+	if arr.Len() != 3 || arr.NullN() != 1 || !arrow.TypeEqual(arr.DataType(), DataType) {
+		t.Fatalf("Unexpected array %v", arr)
+	}
+	expected := []idx.ID{ids[0], idx.NilID, ids[2]}
+	for i, id := range expected {
+		val, err := Value(arr, i)
+		if err != nil {
+			t.Fatalf("Got error while reading value %v", err)
+		}
+		if val != id {
+			t.Fatalf("Value %d (%s) did not match with %s", i, val.String(), id.String())
+		}
+	}
+	view, err := IDs(arr)
+	if err != nil {
+		t.Fatalf("Got error while reading IDs %v", err)
+	}
+	if len(view) != 3 || view[0] != ids[0] || view[2] != ids[2] {
+		t.Fatalf("IDs view %v did not match with %v", view, ids)
+	}
+
+	sliced := array.NewSlice(arr, 2, 3).(*array.FixedSizeBinary)
+	defer sliced.Release()
+	if view, err = IDs(sliced); err != nil || len(view) != 1 || view[0] != ids[2] {
+		t.Fatalf("Sliced view %v did not match with %s: %v", view, ids[2].String(), err)
+	}
+}
+
+func TestFromIDs(t *testing.T) {
+	ids := []idx.ID{idx.NewID(), idx.NewID()}
+	arr := FromIDs(ids)
+	defer arr.Release()
+	view, err := IDs(arr)
+	if err != nil {
+		t.Fatalf("Got error while reading IDs %v", err)
+	}
+	if &view[0] != &ids[0] {
+		t.Fatalf("Array does not share memory with the source slice")
+	}
+	if empty := FromIDs(nil); empty.Len() != 0 {
+		t.Fatalf("Was expecting an empty array")
+	}
+
+	b := array.NewFixedSizeBinaryBuilder(memory.DefaultAllocator, &arrow.FixedSizeBinaryType{ByteWidth: 12})
+	defer b.Release()
+	b.Append(make([]byte, 12))
+	other := b.NewFixedSizeBinaryArray()
+	defer other.Release()
+	if _, err = IDs(other); !errors.Is(err, ErrByteWidth) {
+		t.Fatalf("Was expecting byte width error, got %v", err)
+	}
+	if _, err = Value(other, 0); !errors.Is(err, ErrByteWidth) {
+		t.Fatalf("Was expecting byte width error, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package idx
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+)
+
+// AuditIssue is the problem an AuditFinding reports.
+type AuditIssue string
+
+const (
+	// AuditWrongLength reports values whose length matches none of the formats Scan detects.
+	AuditWrongLength AuditIssue = "wrong_length"
+	// AuditInvalid reports values of a known length that do not decode, or of an unsupported type.
+	AuditInvalid AuditIssue = "invalid"
+	// AuditNilID reports values equal to NilID.
+	AuditNilID AuditIssue = "nil_id"
+	// AuditDuplicate reports IDs already seen in a previous row.
+	AuditDuplicate AuditIssue = "duplicate"
+)
+
+// AuditFinding is a row reported by an Auditor. It encodes to JSON for cleanup jobs.
+type AuditFinding struct {
+	// Key identifies the row, e.g. its primary key. Byte slices are reported like Value.
+	Key interface{} `json:"key"`
+	// Value is the stored value, in hexadecimal unless it is printable text.
+	Value string     `json:"value"`
+	Issue AuditIssue `json:"issue"`
+	// FirstKey is the key of the row first holding a duplicated ID.
+	FirstKey interface{} `json:"first_key,omitempty"`
+	// Error describes why an invalid value does not decode.
+	Error string `json:"error,omitempty"`
+}
+
+// Auditor checks stored ID values one row at a time. It remembers the key of every valid ID to detect
+// duplicates, which takes memory in proportion to the number of rows.
+type Auditor struct {
+	// Scanned is the number of values checked so far.
+	Scanned int64
+	seen    map[ID]interface{}
+}
+
+// NewAuditor returns an empty Auditor.
+func NewAuditor() *Auditor {
+	return &Auditor{seen: make(map[ID]interface{})}
+}
+
+// Check checks the value stored in the row identified by key, accepting every format Scan detects, and
+// reports whether the row has an issue. NULL values are not reported.
+func (a *Auditor) Check(key, value interface{}) (AuditFinding, bool) {
+	a.Scanned++
+	if value == nil {
+		return AuditFinding{}, false
+	}
+	finding := AuditFinding{Key: key, Value: auditValue(value)}
+	var id ID
+	if err := id.Scan(value); err != nil {
+		finding.Issue, finding.Error = AuditInvalid, err.Error()
+		if errors.Is(err, ulid.ErrDataSize) {
+			finding.Issue = AuditWrongLength
+		}
+		return finding, true
+	}
+	if id == NilID {
+		finding.Issue = AuditNilID
+		return finding, true
+	}
+	if first, ok := a.seen[id]; ok {
+		finding.Issue, finding.FirstKey = AuditDuplicate, first
+		return finding, true
+	}
+	a.seen[id] = key
+	return AuditFinding{}, false
+}
+
+// AuditTable streams the key and ID columns of table through an Auditor, calling report for every
+// finding, and returns the number of rows scanned. Returning an error from report stops the audit.
+func AuditTable(ctx context.Context, db *sql.DB, table, key, column string, report func(AuditFinding) error) (int64, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT %s, %s FROM %s", key, column, table))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	a := NewAuditor()
+	for rows.Next() {
+		var k, v interface{}
+		if err = rows.Scan(&k, &v); err != nil {
+			return a.Scanned, err
+		}
+		if b, ok := k.([]byte); ok {
+			// Drivers may reuse the buffer of []byte values.
+			k = auditValue(b)
+		}
+		if finding, ok := a.Check(k, v); ok {
+			if err = report(finding); err != nil {
+				return a.Scanned, err
+			}
+		}
+	}
+	return a.Scanned, rows.Err()
+}
+
+// auditValue returns value as reported in findings.
+func auditValue(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		for _, c := range v {
+			if c < ' ' || c > '~' {
+				return fmt.Sprintf("%x", v)
+			}
+		}
+		return string(v)
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,80 @@
+package idx
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAuditor(t *testing.T) {
+	id := NewID()
+	a := NewAuditor()
+	values := []struct {
+		key   interface{}
+		value interface{}
+		issue AuditIssue
+	}{
+		{1, id[:], ""},
+		{2, nil, ""},
+		{3, NewID().String(), ""},
+		{4, id.UUIDString(), AuditDuplicate},
+		{5, []byte{1, 2, 3}, AuditWrongLength},
+		{6, "01HAK8JPF7S0SFMJ2X96W37WXI", AuditInvalid},
+		{7, NilID[:], AuditNilID},
+		{8, int64(42), AuditInvalid},
+	}
+	for _, v := range values {
+		finding, ok := a.Check(v.key, v.value)
+		if ok != (v.issue != "") || finding.Issue != v.issue {
+			t.Fatalf("Finding %+v for row %v did not match with %q", finding, v.key, v.issue)
+		}
+	}
+	if a.Scanned != int64(len(values)) {
+		t.Fatalf("Was expecting %d scanned values, got %d", len(values), a.Scanned)
+	}
+	finding, _ := NewAuditor().Check(5, []byte{1, 2, 3})
+	if finding.Value != "010203" || finding.Error == "" {
+		t.Fatalf("Unexpected finding %+v", finding)
+	}
+}
+
+func TestAuditTable(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	if _, err = db.Exec("CREATE TABLE orders (pk INTEGER PRIMARY KEY, id BLOB)"); err != nil {
+		t.Fatalf("Got error while creating table %v", err)
+	}
+	id, other := NewID(), NewID()
+	for i, value := range []interface{}{id[:], other[:], []byte("short"), id[:], nil} {
+		if _, err = db.Exec("INSERT INTO orders (pk, id) VALUES (?, ?)", i+1, value); err != nil {
+			t.Fatalf("Got error while inserting row %v", err)
+		}
+	}
+
+	var findings []AuditFinding
+	scanned, err := AuditTable(context.Background(), db, "orders", "pk", "id", func(f AuditFinding) error {
+		findings = append(findings, f)
+		return nil
+	})
+	if err != nil || scanned != 5 {
+		t.Fatalf("Was expecting 5 scanned rows, got %d: %v", scanned, err)
+	}
+	if len(findings) != 2 || findings[0].Issue != AuditWrongLength || findings[1].Issue != AuditDuplicate {
+		t.Fatalf("Unexpected findings %+v", findings)
+	}
+	b, err := json.Marshal(findings[1])
+	if err != nil || string(b) != `{"key":4,"value":"`+auditValue(id[:])+`","issue":"duplicate","first_key":1}` {
+		t.Fatalf("Unexpected report line %s: %v", b, err)
+	}
+
+	stop := errors.New("stop")
+	if _, err = AuditTable(context.Background(), db, "orders", "pk", "id", func(AuditFinding) error { return stop }); !errors.Is(err, stop) {
+		t.Fatalf("Was expecting the report error, got %v", err)
+	}
+}
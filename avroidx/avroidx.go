@@ -0,0 +1,43 @@
+// Package avroidx provides Avro schemas for idx.ID fields, for use with github.com/hamba/avro.
+//
+// idx.ID maps natively to both supported representations: as a [16]byte array to a fixed of size 16,
+// and through its TextMarshaler implementation to a string holding the ULID.
+package avroidx
+
+import (
+	"github.com/hamba/avro/v2"
+	"github.com/ieshan/idx"
+)
+
+// Representation selects how IDs are stored in Avro records.
+type Representation uint8
+
+const (
+	// Fixed stores IDs as their raw 16 bytes in a fixed type named FixedName.
+	Fixed Representation = iota
+	// String stores IDs as 26-character ULID strings.
+	String
+)
+
+// FixedName is the full name of the fixed type used by the Fixed representation.
+const FixedName = "idx.ID"
+
+var (
+	fixedSchema  = avro.MustParse(`{"type":"fixed","name":"ID","namespace":"idx","size":16}`)
+	stringSchema = avro.MustParse(`"string"`)
+)
+
+// Schema returns the schema of an ID field for the given representation. It can be used as a field type when
+// building record schemas programmatically.
+func Schema(repr Representation) avro.Schema {
+	if repr == String {
+		return stringSchema
+	}
+	return fixedSchema
+}
+
+// Register registers idx.ID as the Go type of the FixedName fixed, so IDs inside unions decode into
+// idx.ID instead of a byte array.
+func Register() {
+	avro.Register(FixedName, idx.ID{})
+}
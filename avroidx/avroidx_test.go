@@ -0,0 +1,80 @@
+package avroidx
+
+import (
+	"github.com/hamba/avro/v2"
+	"github.com/ieshan/idx"
+	"testing"
+)
+
+type record struct {
+	ID    idx.ID   `avro:"id"`
+	FkID  *idx.ID  `avro:"fk_id"`
+	Links []idx.ID `avro:"links"`
+}
+
+func recordSchema(t *testing.T, repr Representation) avro.Schema {
+	fields := []*avro.Field{}
+	id, err := avro.NewField("id", Schema(repr))
+	if err != nil {
+		t.Fatalf("Got error while creating field %v", err)
+	}
+	fkUnion, err := avro.NewUnionSchema([]avro.Schema{avro.NewPrimitiveSchema(avro.Null, nil), Schema(repr)})
+	if err != nil {
+		t.Fatalf("Got error while creating union %v", err)
+	}
+	fk, err := avro.NewField("fk_id", fkUnion)
+	if err != nil {
+		t.Fatalf("Got error while creating field %v", err)
+	}
+	links, err := avro.NewField("links", avro.NewArraySchema(Schema(repr)))
+	if err != nil {
+		t.Fatalf("Got error while creating field %v", err)
+	}
+	fields = append(fields, id, fk, links)
+	schema, err := avro.NewRecordSchema("record", "idx.test", fields)
+	if err != nil {
+		t.Fatalf("Got error while creating record schema %v", err)
+	}
+	return schema
+}
+
+func TestSchema(t *testing.T) {
+	Register()
+	id, fk := idx.NewID(), idx.NewID()
+	data := record{ID: id, FkID: &fk, Links: []idx.ID{idx.NewID()}}
+	sizes := map[Representation]int{}
+	for _, repr := range []Representation{Fixed, String} {
+		schema := recordSchema(t, repr)
+		b, err := avro.Marshal(schema, &data)
+		if err != nil {
+			t.Fatalf("Got error while marshaling %v", err)
+		}
+		sizes[repr] = len(b)
+		var result record
+		if err = avro.Unmarshal(schema, b, &result); err != nil {
+			t.Fatalf("Got error while unmarshaling %v", err)
+		}
+		if result.ID != id || result.FkID == nil || *result.FkID != fk || len(result.Links) != 1 || result.Links[0] != data.Links[0] {
+			t.Fatalf("Decoded value %+v did not match with original value %+v", result, data)
+		}
+	}
+	if sizes[Fixed] >= sizes[String] {
+		t.Fatalf("Fixed representation (%d bytes) should be smaller than string (%d bytes)", sizes[Fixed], sizes[String])
+	}
+
+	var decoded interface{}
+	union, err := avro.NewUnionSchema([]avro.Schema{avro.NewPrimitiveSchema(avro.Null, nil), Schema(Fixed)})
+	if err != nil {
+		t.Fatalf("Got error while creating union %v", err)
+	}
+	b, err := avro.Marshal(union, &id)
+	if err != nil {
+		t.Fatalf("Got error while marshaling union %v", err)
+	}
+	if err = avro.Unmarshal(union, b, &decoded); err != nil {
+		t.Fatalf("Got error while unmarshaling union %v", err)
+	}
+	if decoded != id {
+		t.Fatalf("Union value %v did not decode into the original ID %s", decoded, id.String())
+	}
+}
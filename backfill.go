@@ -0,0 +1,50 @@
+package idx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// CreatedAtBackfill fills a NULL timestamp column from the time embedded in the ID column of each row,
+// batch by batch, for tables that dropped their created_at column because the time is in the ID:
+//
+//	ALTER TABLE orders ADD COLUMN created_at TIMESTAMP NULL;
+//
+//	backfill := idx.CreatedAtBackfill{Table: "orders", Key: "id", Target: "created_at"}
+//	err := backfill.Run(ctx, db)
+//
+// The ID column may hold any format Scan detects. Like UUIDConversion, the backfill skips the rows whose
+// target is already set and resumes after After, so it can be restarted after an interruption.
+type CreatedAtBackfill struct {
+	// Table is the table to backfill.
+	Table string
+	// Key is the ID column, which the batches are ordered by.
+	Key string
+	// Target is the timestamp column to fill.
+	Target string
+	// Dialect selects the SQL syntax as in UUIDConversion.
+	Dialect string
+	// BatchSize is the number of rows updated per transaction, 1000 when not set.
+	BatchSize int
+	// After resumes the backfill after the given key value, as reported by Progress.
+	After interface{}
+	// Progress is called after every committed batch.
+	Progress func(ConversionProgress)
+}
+
+// Run backfills the rows, returning at the first invalid ID or database error. Batches committed
+// before the error are kept.
+func (b CreatedAtBackfill) Run(ctx context.Context, db *sql.DB) error {
+	return batchUpdate{
+		table: b.Table, key: b.Key, source: b.Key, target: b.Target, dialect: b.Dialect,
+		size: b.BatchSize, after: b.After, progress: b.Progress,
+		convert: func(key, _ interface{}) (interface{}, error) {
+			var id ID
+			if err := id.Scan(key); err != nil {
+				return nil, fmt.Errorf("idx: backfilling %s %v: %w", b.Key, key, err)
+			}
+			return id.Time(), nil
+		},
+	}.run(ctx, db)
+}
@@ -0,0 +1,72 @@
+package idx
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestCreatedAtBackfill(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	if _, err = db.Exec("CREATE TABLE orders (id BLOB PRIMARY KEY, legacy_id TEXT, created_at TIMESTAMP)"); err != nil {
+		t.Fatalf("Got error while creating table %v", err)
+	}
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]ID, 12)
+	for i := range ids {
+		ids[i] = MinIDAt(start.Add(time.Duration(i) * time.Minute))
+		if _, err = db.Exec("INSERT INTO orders (id, legacy_id) VALUES (?, ?)", ids[i][:], ids[i].UUIDString()); err != nil {
+			t.Fatalf("Got error while inserting row %v", err)
+		}
+	}
+	if _, err = db.Exec("UPDATE orders SET created_at = ? WHERE id = ?", start.Add(-time.Hour), ids[3][:]); err != nil {
+		t.Fatalf("Got error while updating row %v", err)
+	}
+
+	var reports []ConversionProgress
+	backfill := CreatedAtBackfill{Table: "orders", Key: "id", Target: "created_at", BatchSize: 5,
+		Progress: func(p ConversionProgress) { reports = append(reports, p) }}
+	if err = backfill.Run(context.Background(), db); err != nil {
+		t.Fatalf("Got error while backfilling %v", err)
+	}
+	if len(reports) != 3 || reports[2].Converted != 11 {
+		t.Fatalf("Unexpected progress reports %+v", reports)
+	}
+	rows, err := db.Query("SELECT id, created_at FROM orders ORDER BY id")
+	if err != nil {
+		t.Fatalf("Got error while querying %v", err)
+	}
+	for i := 0; rows.Next(); i++ {
+		var id ID
+		var createdAt time.Time
+		if err = rows.Scan(&id, &createdAt); err != nil {
+			t.Fatalf("Got error while scanning %v", err)
+		}
+		expected := id.Time()
+		if i == 3 {
+			expected = start.Add(-time.Hour)
+		}
+		if !createdAt.Equal(expected) {
+			t.Fatalf("Timestamp %s of %s did not match with %s", createdAt, id.String(), expected)
+		}
+	}
+	if err = rows.Close(); err != nil {
+		t.Fatalf("Got error while closing rows %v", err)
+	}
+
+	// Text ID columns are detected as well, and resuming skips the rows before After.
+	if _, err = db.Exec("UPDATE orders SET created_at = NULL"); err != nil {
+		t.Fatalf("Got error while resetting rows %v", err)
+	}
+	reports = nil
+	backfill.Key, backfill.After = "legacy_id", ids[9].UUIDString()
+	if err = backfill.Run(context.Background(), db); err != nil || len(reports) != 1 || reports[0].Converted != 2 {
+		t.Fatalf("Was expecting two rows to backfill, got %+v: %v", reports, err)
+	}
+}
@@ -0,0 +1,65 @@
+package idx
+
+import (
+	"encoding/binary"
+	"github.com/oklog/ulid/v2"
+	"math/bits"
+)
+
+// Base58EncodedSize is the length of the Base58 form of an ID. Shorter values are left-padded with the
+// zero digit '1', so every ID has the same length.
+const Base58EncodedSize = 22
+
+// base58Alphabet is the Bitcoin Base58 alphabet.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Dec = func() (d [256]byte) {
+	for i := range d {
+		d[i] = 0xFF
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		d[base58Alphabet[i]] = byte(i)
+	}
+	return d
+}()
+
+// Base58 returns the ID as a fixed-length, 22-character Base58 string using the Bitcoin alphabet.
+func (id ID) Base58() string {
+	var dst [Base58EncodedSize]byte
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+	for i := Base58EncodedSize - 1; i >= 0; i-- {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, 58)
+		lo, rem = bits.Div64(rem, lo, 58)
+		dst[i] = base58Alphabet[rem]
+	}
+	return string(dst[:])
+}
+
+// FromBase58 parses a string produced by Base58. Only the exact 22-character form is accepted, so every
+// ID has a single valid encoding.
+func FromBase58(val string) (ID, error) {
+	if len(val) != Base58EncodedSize {
+		return NilID, ulid.ErrDataSize
+	}
+	var hi, lo uint64
+	for i := 0; i < len(val); i++ {
+		d := base58Dec[val[i]]
+		if d == 0xFF {
+			return NilID, ulid.ErrInvalidCharacters
+		}
+		// (hi, lo) = (hi, lo) * 58 + d, rejecting values above 128 bits
+		carry, newLo := bits.Mul64(lo, 58)
+		newLo, c := bits.Add64(newLo, uint64(d), 0)
+		overflow, newHi := bits.Mul64(hi, 58)
+		newHi, c = bits.Add64(newHi, carry+c, 0)
+		if overflow != 0 || c != 0 {
+			return NilID, ulid.ErrOverflow
+		}
+		hi, lo = newHi, newLo
+	}
+	var id ID
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
@@ -0,0 +1,63 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestID_Base58(t *testing.T) {
+	max := ID{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	ids := []ID{NilID, NotNullNilID, max}
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, NewID())
+	}
+	for _, id := range ids {
+		encoded := id.Base58()
+		if len(encoded) != Base58EncodedSize {
+			t.Fatalf("Base58 value %s of %s is not %d characters long", encoded, id.String(), Base58EncodedSize)
+		}
+		if expected := base58BigInt(id); encoded != expected {
+			t.Fatalf("Base58 value %s of %s did not match with math/big result %s", encoded, id.String(), expected)
+		}
+		decoded, err := FromBase58(encoded)
+		if err != nil {
+			t.Fatalf("Got error while decoding %s: %v", encoded, err)
+		}
+		if decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+		}
+	}
+	if NilID.Base58() != strings.Repeat("1", Base58EncodedSize) || max.Base58() != "YcVfxkQb6JRzqk5kF2tNLv" {
+		t.Fatalf("Unexpected Base58 boundary values %s %s", NilID.Base58(), max.Base58())
+	}
+}
+
+func TestFromBase58(t *testing.T) {
+	invalid := map[string]error{
+		"":                       ulid.ErrDataSize,
+		"YcVfxkQb6JRzqk5kF2tNL":  ulid.ErrDataSize,
+		"YcVfxkQb6JRzqk5kF2tNL0": ulid.ErrInvalidCharacters,
+		"YcVfxkQb6JRzqk5kF2tNLI": ulid.ErrInvalidCharacters,
+		"YcVfxkQb6JRzqk5kF2tNLw": ulid.ErrOverflow,
+		"zzzzzzzzzzzzzzzzzzzzzz": ulid.ErrOverflow,
+	}
+	for val, expected := range invalid {
+		if _, err := FromBase58(val); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+}
+
+// base58BigInt is a reference Base58 encoder built on math/big.
+func base58BigInt(id ID) string {
+	value, rem, base := new(big.Int).SetBytes(id[:]), new(big.Int), big.NewInt(58)
+	dst := make([]byte, Base58EncodedSize)
+	for i := Base58EncodedSize - 1; i >= 0; i-- {
+		value.DivMod(value, base, rem)
+		dst[i] = base58Alphabet[rem.Int64()]
+	}
+	return string(dst)
+}
@@ -0,0 +1,276 @@
+// Package bigqueryidx saves and loads structs with idx.ID fields in BigQuery
+// (https://pkg.go.dev/cloud.google.com/go/bigquery). The client infers a REPEATED INTEGER column for the
+// [16]byte array behind idx.ID, so rows holding IDs need these helpers to map them to BYTES or STRING
+// columns instead.
+package bigqueryidx
+
+import (
+	"cloud.google.com/go/bigquery"
+	"fmt"
+	"github.com/ieshan/idx"
+	"reflect"
+	"strings"
+)
+
+// Format selects the BigQuery column type of ID fields.
+type Format uint8
+
+const (
+	// Bytes stores IDs as their 16 bytes in BYTES columns.
+	Bytes Format = iota
+	// String stores IDs as ULID strings in STRING columns.
+	String
+)
+
+var (
+	tID      = reflect.TypeOf(idx.ID{})
+	tIDPtr   = reflect.TypeOf((*idx.ID)(nil))
+	tIDSlice = reflect.TypeOf([]idx.ID(nil))
+)
+
+// Value returns id as a BigQuery value in the given format.
+func Value(id idx.ID, format Format) bigquery.Value {
+	if format == String {
+		return id.String()
+	}
+	return id[:]
+}
+
+// FromValue returns the ID held by a BYTES or STRING value. NULL is read as idx.NilID.
+func FromValue(v bigquery.Value) (idx.ID, error) {
+	var id idx.ID
+	switch v := v.(type) {
+	case nil:
+		return idx.NilID, nil
+	case []byte:
+		return id, id.UnmarshalBinary(v)
+	case string:
+		return id, id.UnmarshalText([]byte(v))
+	}
+	return idx.NilID, fmt.Errorf("bigqueryidx: cannot load %T into ID", v)
+}
+
+// InferSchema is like bigquery.InferSchema, but maps the top-level idx.ID, *idx.ID and []idx.ID fields of
+// st to BYTES or STRING columns. *idx.ID fields are nullable.
+func InferSchema(st interface{}, format Format) (bigquery.Schema, error) {
+	t := reflect.TypeOf(st)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bigqueryidx: %T is not a struct", st)
+	}
+	schema, err := bigquery.InferSchema(reflect.New(shadowType(t, format)).Elem().Interface())
+	if err != nil {
+		return nil, err
+	}
+	// BigQuery infers BYTES columns as required, which *idx.ID fields are not.
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() && f.Type == tIDPtr {
+			name := columnName(f)
+			for _, field := range schema {
+				if field.Name == name {
+					field.Required = false
+				}
+			}
+		}
+	}
+	return schema, nil
+}
+
+// Saver implements bigquery.ValueSaver for a struct or struct pointer whose top-level ID fields are
+// converted to the given format, so rows can be streamed with Inserter.Put without shadow string fields.
+// Other fields are saved like bigquery.StructSaver does.
+type Saver struct {
+	Struct   interface{}
+	Format   Format
+	Schema   bigquery.Schema
+	InsertID string
+}
+
+// Save implements bigquery.ValueSaver.
+func (s Saver) Save() (map[string]bigquery.Value, string, error) {
+	src := reflect.Indirect(reflect.ValueOf(s.Struct))
+	if src.Kind() != reflect.Struct {
+		return nil, "", fmt.Errorf("bigqueryidx: %T is not a struct", s.Struct)
+	}
+	dst := reflect.New(shadowType(src.Type(), s.Format)).Elem()
+	j := 0
+	for i := 0; i < src.NumField(); i++ {
+		if !src.Type().Field(i).IsExported() {
+			continue
+		}
+		dst.Field(j).Set(convert(src.Field(i), s.Format))
+		j++
+	}
+	schema := s.Schema
+	if schema == nil {
+		var err error
+		if schema, err = bigquery.InferSchema(dst.Interface()); err != nil {
+			return nil, "", err
+		}
+	}
+	saver := &bigquery.StructSaver{Struct: dst.Interface(), Schema: schema, InsertID: s.InsertID}
+	return saver.Save()
+}
+
+// Loader implements bigquery.ValueLoader for a struct pointer, reading BYTES or STRING columns into its
+// top-level ID fields. Columns are matched case-insensitively to fields by their bigquery tag or name;
+// other fields must have a type the column value is assignable or convertible to.
+type Loader struct {
+	Struct interface{}
+}
+
+// Load implements bigquery.ValueLoader.
+func (l Loader) Load(v []bigquery.Value, s bigquery.Schema) error {
+	dst := reflect.ValueOf(l.Struct)
+	if dst.Kind() != reflect.Pointer || dst.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bigqueryidx: %T is not a struct pointer", l.Struct)
+	}
+	dst = dst.Elem()
+	for i, field := range s {
+		f, ok := fieldByColumn(dst, field.Name)
+		if !ok || i >= len(v) {
+			continue
+		}
+		if err := load(f, v[i]); err != nil {
+			return fmt.Errorf("bigqueryidx: column %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// shadowType returns t with its exported ID fields replaced by the type of the format. Unexported fields
+// are dropped, as BigQuery ignores them.
+func shadowType(t reflect.Type, format Format) reflect.Type {
+	scalar := reflect.TypeOf([]byte(nil))
+	nullable := scalar
+	if format == String {
+		scalar = reflect.TypeOf("")
+		nullable = reflect.TypeOf(bigquery.NullString{})
+	}
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		switch f.Type {
+		case tID:
+			f.Type = scalar
+		case tIDPtr:
+			f.Type = nullable
+		case tIDSlice:
+			f.Type = reflect.SliceOf(scalar)
+		}
+		fields = append(fields, reflect.StructField{Name: f.Name, Type: f.Type, Tag: f.Tag, Anonymous: f.Anonymous})
+	}
+	return reflect.StructOf(fields)
+}
+
+// convert returns the value of v in its shadow type.
+func convert(v reflect.Value, format Format) reflect.Value {
+	switch v.Type() {
+	case tID:
+		return reflect.ValueOf(Value(v.Interface().(idx.ID), format))
+	case tIDPtr:
+		id := v.Interface().(*idx.ID)
+		if format == String {
+			if id == nil {
+				return reflect.ValueOf(bigquery.NullString{})
+			}
+			return reflect.ValueOf(bigquery.NullString{StringVal: id.String(), Valid: true})
+		}
+		if id == nil {
+			return reflect.ValueOf([]byte(nil))
+		}
+		return reflect.ValueOf(id[:])
+	case tIDSlice:
+		ids := v.Interface().([]idx.ID)
+		if format == String {
+			values := make([]string, len(ids))
+			for i, id := range ids {
+				values[i] = id.String()
+			}
+			return reflect.ValueOf(values)
+		}
+		values := make([][]byte, len(ids))
+		for i := range ids {
+			values[i] = ids[i][:]
+		}
+		return reflect.ValueOf(values)
+	}
+	return v
+}
+
+// fieldByColumn returns the exported field of v stored in the named column.
+func fieldByColumn(v reflect.Value, column string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if name := columnName(f); name != "-" && strings.EqualFold(name, column) {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// columnName returns the column name of f, from its bigquery tag or its name.
+func columnName(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("bigquery"), ","); name != "" {
+		return name
+	}
+	return f.Name
+}
+
+// load stores the column value in f.
+func load(f reflect.Value, value bigquery.Value) error {
+	switch f.Type() {
+	case tID:
+		id, err := FromValue(value)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(id))
+		return nil
+	case tIDPtr:
+		if value == nil {
+			f.Set(reflect.Zero(tIDPtr))
+			return nil
+		}
+		id, err := FromValue(value)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(&id))
+		return nil
+	case tIDSlice:
+		values, _ := value.([]bigquery.Value)
+		ids := make([]idx.ID, len(values))
+		for i, v := range values {
+			var err error
+			if ids[i], err = FromValue(v); err != nil {
+				return err
+			}
+		}
+		f.Set(reflect.ValueOf(ids))
+		return nil
+	}
+	if value == nil {
+		f.Set(reflect.Zero(f.Type()))
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Type().AssignableTo(f.Type()):
+		f.Set(rv)
+	case rv.Type().ConvertibleTo(f.Type()):
+		f.Set(rv.Convert(f.Type()))
+	default:
+		return fmt.Errorf("cannot load %T into %s", value, f.Type())
+	}
+	return nil
+}
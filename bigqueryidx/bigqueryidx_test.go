@@ -0,0 +1,94 @@
+package bigqueryidx
+
+import (
+	"cloud.google.com/go/bigquery"
+	"github.com/ieshan/idx"
+	"slices"
+	"testing"
+)
+
+type event struct {
+	ID       idx.ID   `bigquery:"id"`
+	ParentID *idx.ID  `bigquery:"parent_id"`
+	Links    []idx.ID `bigquery:"links"`
+	Name     string
+	Count    int64
+	internal string
+}
+
+func TestInferSchema(t *testing.T) {
+	for format, expected := range map[Format]bigquery.FieldType{Bytes: bigquery.BytesFieldType, String: bigquery.StringFieldType} {
+		schema, err := InferSchema(&event{}, format)
+		if err != nil {
+			t.Fatalf("Got error while inferring schema %v", err)
+		}
+		if len(schema) != 5 {
+			t.Fatalf("Schema has %d fields, was expecting 5", len(schema))
+		}
+		for _, field := range schema[:3] {
+			if field.Type != expected {
+				t.Fatalf("Field %s has type %s, was expecting %s", field.Name, field.Type, expected)
+			}
+		}
+		if !schema[0].Required || schema[1].Required || !schema[2].Repeated {
+			t.Fatalf("Unexpected field modes %+v", schema[:3])
+		}
+	}
+	if _, err := InferSchema(42, Bytes); err == nil {
+		t.Fatalf("Was expecting error for non-struct value")
+	}
+}
+
+func TestSaver(t *testing.T) {
+	parent := idx.NewID()
+	data := event{ID: idx.NewID(), ParentID: &parent, Links: []idx.ID{idx.NewID()}, Name: "a", Count: 2}
+	for _, format := range []Format{Bytes, String} {
+		schema, _ := InferSchema(data, format)
+		row, insertID, err := Saver{Struct: &data, Format: format, InsertID: "row-1"}.Save()
+		if err != nil {
+			t.Fatalf("Got error while saving row %v", err)
+		}
+		if insertID != "row-1" || row["id"] == nil || row["Name"] != "a" {
+			t.Fatalf("Unexpected row %v", row)
+		}
+
+		values := make([]bigquery.Value, len(schema))
+		for i, field := range schema {
+			values[i] = row[field.Name]
+		}
+		// Repeated and nullable values are loaded as []bigquery.Value and nil.
+		values[2] = []bigquery.Value{Value(data.Links[0], format)}
+		if format == String {
+			values[1] = parent.String()
+		}
+		var result event
+		if err = (Loader{Struct: &result}).Load(values, schema); err != nil {
+			t.Fatalf("Got error while loading row %v", err)
+		}
+		if result.ID != data.ID || result.ParentID == nil || *result.ParentID != parent || !slices.Equal(result.Links, data.Links) || result.Name != "a" || result.Count != 2 {
+			t.Fatalf("Loaded row %+v did not match with %+v", result, data)
+		}
+		values[1] = nil
+		if err = (Loader{Struct: &result}).Load(values, schema); err != nil || result.ParentID != nil {
+			t.Fatalf("Was expecting nil parent, got %v: %v", result.ParentID, err)
+		}
+	}
+	if _, _, err := (Saver{Struct: 42}).Save(); err == nil {
+		t.Fatalf("Was expecting error for non-struct value")
+	}
+}
+
+func TestFromValue(t *testing.T) {
+	id := idx.NewID()
+	for _, v := range []bigquery.Value{id[:], id.String()} {
+		if result, err := FromValue(v); err != nil || result != id {
+			t.Fatalf("Original ID (%s) did not match with loaded ID (%s): %v", id.String(), result.String(), err)
+		}
+	}
+	if result, err := FromValue(nil); err != nil || result != idx.NilID {
+		t.Fatalf("Was expecting NilID for NULL, got %s: %v", result.String(), err)
+	}
+	if _, err := FromValue(int64(1)); err == nil {
+		t.Fatalf("Was expecting error for integer value")
+	}
+}
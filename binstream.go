@@ -0,0 +1,174 @@
+package idx
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+)
+
+// ErrStreamHeader is returned by ReadIDs when the input does not start with a valid stream header.
+var ErrStreamHeader = errors.New("idx: invalid id stream header")
+
+const (
+	// streamMagic starts every binary ID stream, followed by streamVersion and the big-endian uint64 count.
+	streamMagic      = "IDX"
+	streamVersion    = 1
+	streamHeaderSize = len(streamMagic) + 1 + 8
+	// streamChunk is the number of IDs buffered per read or write call.
+	streamChunk = 256
+)
+
+// WriteIDs writes ids to w as a 12-byte header followed by the 16 bytes of each ID. The header holds the
+// magic "IDX", a version byte and the number of IDs as a big-endian uint64.
+func WriteIDs(w io.Writer, ids []ID) error {
+	_, err := writeIDs(w, ids)
+	return err
+}
+
+// ReadIDs reads a stream written by WriteIDs. A stream that ends before the announced number of IDs
+// returns io.ErrUnexpectedEOF.
+func ReadIDs(r io.Reader) ([]ID, error) {
+	ids, _, err := readIDs(r)
+	return ids, err
+}
+
+// IDStream is a list of IDs implementing io.WriterTo and io.ReaderFrom with the WriteIDs format.
+type IDStream []ID
+
+// WriteTo implements io.WriterTo.
+func (s IDStream) WriteTo(w io.Writer) (int64, error) {
+	return writeIDs(w, s)
+}
+
+// ReadFrom implements io.ReaderFrom. The IDs read replace the contents of s.
+func (s *IDStream) ReadFrom(r io.Reader) (int64, error) {
+	ids, n, err := readIDs(r)
+	*s = ids
+	return n, err
+}
+
+func writeIDs(w io.Writer, ids []ID) (int64, error) {
+	buf := make([]byte, 0, streamChunk*len(NilID))
+	buf = append(buf, streamMagic...)
+	buf = append(buf, streamVersion)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(len(ids)))
+	var total int64
+	for i := range ids {
+		if len(buf)+len(NilID) > cap(buf) {
+			n, err := w.Write(buf)
+			total += int64(n)
+			if err != nil {
+				return total, err
+			}
+			buf = buf[:0]
+		}
+		buf = append(buf, ids[i][:]...)
+	}
+	n, err := w.Write(buf)
+	return total + int64(n), err
+}
+
+func readIDs(r io.Reader) ([]ID, int64, error) {
+	count, total, err := readStreamHeader(r)
+	if err != nil {
+		return nil, total, err
+	}
+	// The count is not trusted for preallocation, so a corrupt header cannot exhaust memory.
+	ids := make([]ID, 0, min(count, streamChunk))
+	buf := make([]byte, streamChunk*len(NilID))
+	for remaining := count; remaining > 0; {
+		chunk := min(remaining, streamChunk)
+		n, err := io.ReadFull(r, buf[:chunk*uint64(len(NilID))])
+		total += int64(n)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return ids, total, err
+		}
+		for i := 0; i < n; i += len(NilID) {
+			ids = append(ids, ID(buf[i:i+len(NilID)]))
+		}
+		remaining -= chunk
+	}
+	return ids, total, nil
+}
+
+// readStreamHeader reads the header written by writeIDs and returns the announced number of IDs.
+func readStreamHeader(r io.Reader) (uint64, int64, error) {
+	var header [streamHeaderSize]byte
+	n, err := io.ReadFull(r, header[:])
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return 0, int64(n), ErrStreamHeader
+		}
+		return 0, int64(n), err
+	}
+	if string(header[:len(streamMagic)]) != streamMagic || header[len(streamMagic)] != streamVersion {
+		return 0, int64(n), ErrStreamHeader
+	}
+	return binary.BigEndian.Uint64(header[len(streamMagic)+1:]), int64(n), nil
+}
+
+// IDReader reads a stream written by WriteIDs one ID at a time, for streams too large to hold in memory.
+type IDReader struct {
+	r         *bufio.Reader
+	remaining uint64
+	started   bool
+	err       error
+}
+
+// NewIDReader returns an IDReader reading from r. The header is read along with the first ID.
+func NewIDReader(r io.Reader) *IDReader {
+	return &IDReader{r: bufio.NewReaderSize(r, streamChunk*len(NilID))}
+}
+
+// Next returns the next ID of the stream. io.EOF is returned once the announced number of IDs was read,
+// and io.ErrUnexpectedEOF if the stream ends before.
+func (r *IDReader) Next() (ID, error) {
+	if r.err != nil {
+		return NilID, r.err
+	}
+	if !r.started {
+		r.started = true
+		if r.remaining, _, r.err = readStreamHeader(r.r); r.err != nil {
+			return NilID, r.err
+		}
+	}
+	if r.remaining == 0 {
+		r.err = io.EOF
+		return NilID, r.err
+	}
+	var id ID
+	if _, err := io.ReadFull(r.r, id[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		r.err = err
+		return NilID, err
+	}
+	r.remaining--
+	return id, nil
+}
+
+// All returns an iterator over the remaining IDs. It stops at the first error, reported by Err.
+func (r *IDReader) All() iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		for {
+			id, err := r.Next()
+			if err != nil || !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the error that stopped the reader, or nil once the whole stream was read.
+func (r *IDReader) Err() error {
+	if errors.Is(r.err, io.EOF) {
+		return nil
+	}
+	return r.err
+}
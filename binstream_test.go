@@ -0,0 +1,117 @@
+package idx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestWriteIDs(t *testing.T) {
+	ids := make([]ID, 1000)
+	for i := range ids {
+		ids[i] = NewID()
+	}
+	var buf bytes.Buffer
+	if err := WriteIDs(&buf, ids); err != nil {
+		t.Fatalf("Got error while writing IDs %v", err)
+	}
+	if buf.Len() != streamHeaderSize+len(ids)*16 || !bytes.HasPrefix(buf.Bytes(), []byte("IDX\x01")) {
+		t.Fatalf("Unexpected stream of %d bytes", buf.Len())
+	}
+	result, err := ReadIDs(&buf)
+	if err != nil {
+		t.Fatalf("Got error while reading IDs %v", err)
+	}
+	if len(result) != len(ids) {
+		t.Fatalf("Read %d IDs, was expecting %d", len(result), len(ids))
+	}
+	for i := range ids {
+		if result[i] != ids[i] {
+			t.Fatalf("Original ID (%s) did not match with read ID (%s)", ids[i].String(), result[i].String())
+		}
+	}
+
+	buf.Reset()
+	if err = WriteIDs(&buf, nil); err != nil {
+		t.Fatalf("Got error while writing IDs %v", err)
+	}
+	if result, err = ReadIDs(&buf); err != nil || len(result) != 0 {
+		t.Fatalf("Was expecting empty list, got %v: %v", result, err)
+	}
+}
+
+func TestReadIDs(t *testing.T) {
+	var buf bytes.Buffer
+	_ = WriteIDs(&buf, []ID{NewID(), NewID()})
+	stream := buf.Bytes()
+	invalid := map[string]error{
+		"":                                ErrStreamHeader,
+		"IDX":                             ErrStreamHeader,
+		"XYZ" + string(stream[3:]):        ErrStreamHeader,
+		"IDX\x02" + string(stream[4:]):    ErrStreamHeader,
+		string(stream[:len(stream)-1]):    io.ErrUnexpectedEOF,
+		string(stream[:streamHeaderSize]): io.ErrUnexpectedEOF,
+	}
+	for val, expected := range invalid {
+		if _, err := ReadIDs(bytes.NewReader([]byte(val))); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+}
+
+func TestIDStream(t *testing.T) {
+	ids := IDStream{NewID(), NewID()}
+	var buf bytes.Buffer
+	var w io.WriterTo = ids
+	n, err := w.WriteTo(&buf)
+	if err != nil || n != int64(streamHeaderSize+32) {
+		t.Fatalf("Unexpected write of %d bytes: %v", n, err)
+	}
+	var result IDStream
+	var r io.ReaderFrom = &result
+	if n, err = r.ReadFrom(&buf); err != nil || n != int64(streamHeaderSize+32) {
+		t.Fatalf("Unexpected read of %d bytes: %v", n, err)
+	}
+	if len(result) != 2 || result[0] != ids[0] || result[1] != ids[1] {
+		t.Fatalf("Read IDs %v did not match with %v", result, ids)
+	}
+}
+
+func TestIDReader(t *testing.T) {
+	ids := randomIDs(600)
+	var buf bytes.Buffer
+	_ = WriteIDs(&buf, ids)
+	stream := buf.Bytes()
+
+	r := NewIDReader(bytes.NewReader(stream))
+	var result []ID
+	for id := range r.All() {
+		result = append(result, id)
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("Got error while reading IDs %v", err)
+	}
+	if len(result) != len(ids) {
+		t.Fatalf("Read %d IDs, was expecting %d", len(result), len(ids))
+	}
+	for i := range ids {
+		if result[i] != ids[i] {
+			t.Fatalf("Original ID (%s) did not match with read ID (%s)", ids[i].String(), result[i].String())
+		}
+	}
+	if _, err := r.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Was expecting io.EOF, got %v", err)
+	}
+
+	r = NewIDReader(bytes.NewReader(stream[:len(stream)-1]))
+	for range r.All() {
+	}
+	if err := r.Err(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Was expecting io.ErrUnexpectedEOF, got %v", err)
+	}
+	r = NewIDReader(bytes.NewReader([]byte("XYZ")))
+	if _, err := r.Next(); !errors.Is(err, ErrStreamHeader) || !errors.Is(r.Err(), ErrStreamHeader) {
+		t.Fatalf("Was expecting ErrStreamHeader, got %v", err)
+	}
+}
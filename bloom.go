@@ -0,0 +1,119 @@
+package idx
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// ErrBloomFilter is returned by BloomFilter.UnmarshalBinary for malformed input.
+var ErrBloomFilter = errors.New("idx: invalid bloom filter encoding")
+
+// bloomMagic starts the binary form of a BloomFilter, followed by a version byte, the number of hash
+// functions as a uint32 and the number of bits as a uint64, both big-endian, and the bit words.
+const (
+	bloomMagic      = "IDXB"
+	bloomVersion    = 1
+	bloomHeaderSize = len(bloomMagic) + 1 + 4 + 8
+)
+
+// BloomFilter is a Bloom filter of IDs: MayContain never misses an added ID and wrongly reports other
+// IDs at the false-positive rate it was sized for, so edge services can skip lookups of IDs that were
+// definitely never seen. Positions are derived from the ID bytes without a seed, so encoded filters can
+// be shared between processes. A BloomFilter is not safe for concurrent modification.
+type BloomFilter struct {
+	words []uint64
+	m     uint64
+	k     uint32
+}
+
+// NewBloomFilter returns a filter sized for n IDs at the false-positive rate p, e.g. 0.01.
+func NewBloomFilter(n int, p float64) *BloomFilter {
+	n = max(n, 1)
+	p = min(max(p, 1e-12), 0.5)
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = max((m+63)/64*64, 64)
+	k := uint32(max(math.Round(float64(m)/float64(n)*math.Ln2), 1))
+	return &BloomFilter{words: make([]uint64, m/64), m: m, k: k}
+}
+
+// Add adds id to the filter.
+func (f *BloomFilter) Add(id ID) {
+	h1, h2 := bloomHashes(id)
+	for i := uint32(0); i < f.k; i++ {
+		pos := (h1 + uint64(i)*h2) % f.m
+		f.words[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// MayContain reports whether id may have been added. A false result is always right.
+func (f *BloomFilter) MayContain(id ID) bool {
+	h1, h2 := bloomHashes(id)
+	for i := uint32(0); i < f.k; i++ {
+		pos := (h1 + uint64(i)*h2) % f.m
+		if f.words[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// EstimatedFalsePositiveRate returns the false-positive rate for the current fill of the filter.
+func (f *BloomFilter) EstimatedFalsePositiveRate() float64 {
+	set := 0
+	for _, w := range f.words {
+		set += bits.OnesCount64(w)
+	}
+	return math.Pow(float64(set)/float64(f.m), float64(f.k))
+}
+
+// MarshalBinary encodes the filter. See https://pkg.go.dev/encoding#BinaryMarshaler
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, bloomHeaderSize+len(f.words)*8)
+	b = append(b, bloomMagic...)
+	b = append(b, bloomVersion)
+	b = binary.BigEndian.AppendUint32(b, f.k)
+	b = binary.BigEndian.AppendUint64(b, f.m)
+	for _, w := range f.words {
+		b = binary.BigEndian.AppendUint64(b, w)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes a filter encoded by MarshalBinary. See https://pkg.go.dev/encoding#BinaryUnmarshaler
+func (f *BloomFilter) UnmarshalBinary(b []byte) error {
+	if len(b) < bloomHeaderSize || string(b[:len(bloomMagic)]) != bloomMagic || b[len(bloomMagic)] != bloomVersion {
+		return ErrBloomFilter
+	}
+	k := binary.BigEndian.Uint32(b[len(bloomMagic)+1:])
+	m := binary.BigEndian.Uint64(b[len(bloomMagic)+5:])
+	b = b[bloomHeaderSize:]
+	if k == 0 || m == 0 || m%64 != 0 || uint64(len(b)) != m/8 {
+		return ErrBloomFilter
+	}
+	words := make([]uint64, m/64)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint64(b[i*8:])
+	}
+	*f = BloomFilter{words: words, m: m, k: k}
+	return nil
+}
+
+// bloomHashes returns the two hashes combined into the positions of id, mixing both halves so IDs that
+// differ only slightly, such as monotonic IDs of the same millisecond, spread over the filter.
+func bloomHashes(id ID) (uint64, uint64) {
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+	h1 := mix64(lo ^ mix64(hi))
+	h2 := mix64(hi^mix64(lo^0x9e3779b97f4a7c15)) | 1
+	return h1, h2
+}
+
+// mix64 is the finalizer of SplitMix64.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
@@ -0,0 +1,65 @@
+package idx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+	const n = 20000
+	f := NewBloomFilter(n, 0.01)
+	ids := make([]ID, n)
+	for i := range ids {
+		ids[i] = NewID()
+		f.Add(ids[i])
+	}
+	for _, id := range ids {
+		if !f.MayContain(id) {
+			t.Fatalf("Filter misses the added ID %s", id.String())
+		}
+	}
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if f.MayContain(NewID()) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / n; rate > 0.02 {
+		t.Fatalf("False-positive rate %f is above the expected 0.01", rate)
+	}
+	if rate := f.EstimatedFalsePositiveRate(); rate < 0.005 || rate > 0.02 {
+		t.Fatalf("Estimated false-positive rate %f is not close to 0.01", rate)
+	}
+	if NewBloomFilter(10, 0.01).MayContain(ids[0]) {
+		t.Fatalf("Empty filter reports an ID")
+	}
+}
+
+func TestBloomFilter_MarshalBinary(t *testing.T) {
+	f := NewBloomFilter(1000, 0.001)
+	ids := []ID{NewID(), NewID(), NewID()}
+	for _, id := range ids {
+		f.Add(id)
+	}
+	b, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Got error while encoding filter %v", err)
+	}
+	var result BloomFilter
+	if err = result.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Got error while decoding filter %v", err)
+	}
+	for _, id := range ids {
+		if !result.MayContain(id) {
+			t.Fatalf("Decoded filter misses the added ID %s", id.String())
+		}
+	}
+	if result.k != f.k || result.m != f.m {
+		t.Fatalf("Decoded filter parameters did not match")
+	}
+	for _, input := range [][]byte{nil, b[:len(b)-1], append([]byte("XXXX"), b[4:]...)} {
+		if err = result.UnmarshalBinary(input); !errors.Is(err, ErrBloomFilter) {
+			t.Fatalf("Was expecting bloom filter error, got %v", err)
+		}
+	}
+}
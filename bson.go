@@ -0,0 +1,83 @@
+package idx
+
+import (
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// MarshalBSONValue stores the IDX as BSON binary subtype 0x04 (UUID), so documents display properly in
+// Compass and interoperate with the UUID handling of other drivers.
+// See https://pkg.go.dev/go.mongodb.org/mongo-driver/bson#ValueMarshaler
+func (id ID) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bsontype.Binary, bsoncore.AppendBinary(nil, bsontype.BinaryUUID, id[:]), nil
+}
+
+// UnmarshalBSONValue populates the IDX from BSON binary data or a 26-character ULID string. Besides subtype 0x04,
+// the generic subtype 0x00 written by earlier versions is accepted, so existing documents remain readable.
+// Legacy ObjectIDs are mapped with FromObjectID.
+// BSON null and undefined decode as NilID. See https://pkg.go.dev/go.mongodb.org/mongo-driver/bson#ValueUnmarshaler
+func (id *ID) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	switch t {
+	case bsontype.ObjectID:
+		oid, _, ok := bsoncore.ReadObjectID(data)
+		if !ok {
+			return fmt.Errorf("idx: malformed BSON ObjectID value")
+		}
+		*id = FromObjectID(oid)
+		return nil
+	case bsontype.String:
+		str, _, ok := bsoncore.ReadString(data)
+		if !ok {
+			return fmt.Errorf("idx: malformed BSON string value")
+		}
+		return decodeText(str, id)
+	case bsontype.Null, bsontype.Undefined:
+		*id = NilID
+		return nil
+	case bsontype.Binary:
+		subtype, b, _, ok := bsoncore.ReadBinary(data)
+		if !ok {
+			return fmt.Errorf("idx: malformed BSON binary value")
+		}
+		if subtype != bsontype.BinaryUUID && subtype != bsontype.BinaryGeneric {
+			return fmt.Errorf("idx: cannot decode BSON binary subtype 0x%02x into an ID", subtype)
+		}
+		return id.UnmarshalBinary(b)
+	}
+	return fmt.Errorf("idx: cannot decode BSON %s into an ID", t)
+}
+
+// StringID is an ID stored in MongoDB as its 26-character ULID string instead of binary, for collections
+// shared with services that persist string ULIDs. Both representations are accepted when decoding.
+type StringID ID
+
+// ID returns the StringID as an ID.
+func (id StringID) ID() ID {
+	return ID(id)
+}
+
+func (id StringID) String() string {
+	return ID(id).String()
+}
+
+// MarshalText returns the ULID string of the StringID. See https://pkg.go.dev/encoding#TextMarshaler
+func (id StringID) MarshalText() ([]byte, error) {
+	return ID(id).MarshalText()
+}
+
+// UnmarshalText populates the StringID from a ULID string. See https://pkg.go.dev/encoding#TextUnmarshaler
+func (id *StringID) UnmarshalText(b []byte) error {
+	return (*ID)(id).UnmarshalText(b)
+}
+
+// MarshalBSONValue stores the StringID as a BSON string. See https://pkg.go.dev/go.mongodb.org/mongo-driver/bson#ValueMarshaler
+func (id StringID) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bsontype.String, bsoncore.AppendString(nil, ID(id).String()), nil
+}
+
+// UnmarshalBSONValue populates the StringID from either a BSON string or binary value.
+// See https://pkg.go.dev/go.mongodb.org/mongo-driver/bson#ValueUnmarshaler
+func (id *StringID) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	return (*ID)(id).UnmarshalBSONValue(t, data)
+}
@@ -0,0 +1,240 @@
+package idx
+
+import (
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"reflect"
+)
+
+// BSONRepresentation selects how RegisterBSONCodec stores IDs in MongoDB.
+type BSONRepresentation uint8
+
+const (
+	// BSONBinaryUUID stores IDs as binary subtype 0x04, which is also what ID.MarshalBSONValue produces.
+	BSONBinaryUUID BSONRepresentation = iota
+	// BSONBinaryGeneric stores IDs as binary subtype 0x00, matching the driver's default for byte arrays.
+	BSONBinaryGeneric
+	// BSONString stores IDs as 26-character ULID strings.
+	BSONString
+)
+
+// BSONOption configures RegisterBSONCodec.
+type BSONOption func(*bsonCodec)
+
+// WithBSONRepresentation selects the representation written by the codec. Every representation is
+// accepted when decoding.
+func WithBSONRepresentation(repr BSONRepresentation) BSONOption {
+	return func(c *bsonCodec) {
+		c.repr = repr
+	}
+}
+
+var (
+	tID      = reflect.TypeOf(ID{})
+	tIDPtr   = reflect.TypeOf((*ID)(nil))
+	tIDSlice = reflect.TypeOf([]ID(nil))
+	tNullID  = reflect.TypeOf(NullID{})
+)
+
+// RegisterBSONCodec installs encoders and decoders for ID, *ID, NullID and []ID on reg, so the representation is
+// configured once when setting up the client:
+//
+//	reg := bson.NewRegistry()
+//	idx.RegisterBSONCodec(reg, idx.WithBSONRepresentation(idx.BSONString))
+//	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetRegistry(reg))
+func RegisterBSONCodec(reg *bsoncodec.Registry, opts ...BSONOption) {
+	c := &bsonCodec{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	reg.RegisterTypeEncoder(tID, bsoncodec.ValueEncoderFunc(c.encodeID))
+	reg.RegisterTypeDecoder(tID, bsoncodec.ValueDecoderFunc(c.decodeID))
+	reg.RegisterTypeEncoder(tIDPtr, bsoncodec.ValueEncoderFunc(c.encodeIDPtr))
+	reg.RegisterTypeDecoder(tIDPtr, bsoncodec.ValueDecoderFunc(c.decodeIDPtr))
+	reg.RegisterTypeEncoder(tNullID, bsoncodec.ValueEncoderFunc(c.encodeNullID))
+	reg.RegisterTypeDecoder(tNullID, bsoncodec.ValueDecoderFunc(c.decodeNullID))
+	reg.RegisterTypeEncoder(tIDSlice, bsoncodec.ValueEncoderFunc(c.encodeIDSlice))
+	reg.RegisterTypeDecoder(tIDSlice, bsoncodec.ValueDecoderFunc(c.decodeIDSlice))
+}
+
+type bsonCodec struct {
+	repr BSONRepresentation
+}
+
+func (c *bsonCodec) encodeID(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tID {
+		return bsoncodec.ValueEncoderError{Name: "IDEncodeValue", Types: []reflect.Type{tID}, Received: val}
+	}
+	return c.write(vw, val.Interface().(ID))
+}
+
+func (c *bsonCodec) decodeID(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tID {
+		return bsoncodec.ValueDecoderError{Name: "IDDecodeValue", Types: []reflect.Type{tID}, Received: val}
+	}
+	id, err := c.read(vr)
+	if err != nil {
+		return err
+	}
+	val.Set(reflect.ValueOf(id))
+	return nil
+}
+
+func (c *bsonCodec) encodeIDPtr(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tIDPtr {
+		return bsoncodec.ValueEncoderError{Name: "IDPtrEncodeValue", Types: []reflect.Type{tIDPtr}, Received: val}
+	}
+	if val.IsNil() {
+		return vw.WriteNull()
+	}
+	return c.write(vw, *val.Interface().(*ID))
+}
+
+func (c *bsonCodec) decodeIDPtr(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tIDPtr {
+		return bsoncodec.ValueDecoderError{Name: "IDPtrDecodeValue", Types: []reflect.Type{tIDPtr}, Received: val}
+	}
+	if vr.Type() == bsontype.Null {
+		val.Set(reflect.Zero(tIDPtr))
+		return vr.ReadNull()
+	}
+	id, err := c.read(vr)
+	if err != nil {
+		return err
+	}
+	val.Set(reflect.ValueOf(&id))
+	return nil
+}
+
+func (c *bsonCodec) encodeNullID(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tNullID {
+		return bsoncodec.ValueEncoderError{Name: "NullIDEncodeValue", Types: []reflect.Type{tNullID}, Received: val}
+	}
+	n := val.Interface().(NullID)
+	if !n.Valid {
+		return vw.WriteNull()
+	}
+	return c.write(vw, n.ID)
+}
+
+func (c *bsonCodec) decodeNullID(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tNullID {
+		return bsoncodec.ValueDecoderError{Name: "NullIDDecodeValue", Types: []reflect.Type{tNullID}, Received: val}
+	}
+	switch vr.Type() {
+	case bsontype.Null:
+		val.Set(reflect.ValueOf(NullID{}))
+		return vr.ReadNull()
+	case bsontype.Undefined:
+		val.Set(reflect.ValueOf(NullID{}))
+		return vr.ReadUndefined()
+	}
+	id, err := c.read(vr)
+	if err != nil {
+		return err
+	}
+	val.Set(reflect.ValueOf(NewNullID(id)))
+	return nil
+}
+
+func (c *bsonCodec) encodeIDSlice(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tIDSlice {
+		return bsoncodec.ValueEncoderError{Name: "IDSliceEncodeValue", Types: []reflect.Type{tIDSlice}, Received: val}
+	}
+	if val.IsNil() {
+		return vw.WriteNull()
+	}
+	aw, err := vw.WriteArray()
+	if err != nil {
+		return err
+	}
+	for _, id := range val.Interface().([]ID) {
+		evw, err := aw.WriteArrayElement()
+		if err != nil {
+			return err
+		}
+		if err = c.write(evw, id); err != nil {
+			return err
+		}
+	}
+	return aw.WriteArrayEnd()
+}
+
+func (c *bsonCodec) decodeIDSlice(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tIDSlice {
+		return bsoncodec.ValueDecoderError{Name: "IDSliceDecodeValue", Types: []reflect.Type{tIDSlice}, Received: val}
+	}
+	switch vr.Type() {
+	case bsontype.Null:
+		val.Set(reflect.Zero(tIDSlice))
+		return vr.ReadNull()
+	case bsontype.Array:
+	default:
+		return fmt.Errorf("idx: cannot decode BSON %s into []ID", vr.Type())
+	}
+	ar, err := vr.ReadArray()
+	if err != nil {
+		return err
+	}
+	ids := make([]ID, 0)
+	for {
+		evr, err := ar.ReadValue()
+		if err == bsonrw.ErrEOA {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		id, err := c.read(evr)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	val.Set(reflect.ValueOf(ids))
+	return nil
+}
+
+func (c *bsonCodec) write(vw bsonrw.ValueWriter, id ID) error {
+	switch c.repr {
+	case BSONString:
+		return vw.WriteString(id.String())
+	case BSONBinaryGeneric:
+		return vw.WriteBinaryWithSubtype(id[:], bsontype.BinaryGeneric)
+	}
+	return vw.WriteBinaryWithSubtype(id[:], bsontype.BinaryUUID)
+}
+
+func (c *bsonCodec) read(vr bsonrw.ValueReader) (ID, error) {
+	switch vr.Type() {
+	case bsontype.Null:
+		return NilID, vr.ReadNull()
+	case bsontype.Undefined:
+		return NilID, vr.ReadUndefined()
+	case bsontype.ObjectID:
+		oid, err := vr.ReadObjectID()
+		if err != nil {
+			return NilID, err
+		}
+		return FromObjectID(oid), nil
+	case bsontype.String:
+		str, err := vr.ReadString()
+		if err != nil {
+			return NilID, err
+		}
+		return FromString(str)
+	case bsontype.Binary:
+		data, subtype, err := vr.ReadBinary()
+		if err != nil {
+			return NilID, err
+		}
+		if subtype != bsontype.BinaryUUID && subtype != bsontype.BinaryGeneric {
+			return NilID, fmt.Errorf("idx: cannot decode BSON binary subtype 0x%02x into an ID", subtype)
+		}
+		var id ID
+		return id, id.UnmarshalBinary(data)
+	}
+	return NilID, fmt.Errorf("idx: cannot decode BSON %s into an ID", vr.Type())
+}
@@ -0,0 +1,86 @@
+package idx
+
+import (
+	"bytes"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"testing"
+)
+
+func TestRegisterBSONCodec(t *testing.T) {
+	type IdTestStruct struct {
+		ID         ID     `bson:"_id"`
+		ParentID   *ID    `bson:"parent_id"`
+		OwnerID    *ID    `bson:"owner_id"`
+		ReviewerID NullID `bson:"reviewer_id"`
+		EditorID   NullID `bson:"editor_id"`
+		Tags       []ID   `bson:"tags"`
+		Links      []ID   `bson:"links"`
+	}
+	id, parent, reviewer := NewID(), NewID(), NewID()
+	data := IdTestStruct{ID: id, ParentID: &parent, ReviewerID: NewNullID(reviewer), Tags: []ID{NewID(), NewID()}}
+
+	reprs := map[BSONRepresentation]bsontype.Type{
+		BSONBinaryUUID:    bsontype.Binary,
+		BSONBinaryGeneric: bsontype.Binary,
+		BSONString:        bsontype.String,
+	}
+	for repr, expectedType := range reprs {
+		reg := bson.NewRegistry()
+		RegisterBSONCodec(reg, WithBSONRepresentation(repr))
+
+		buf := new(bytes.Buffer)
+		vw, err := bsonrw.NewBSONValueWriter(buf)
+		if err != nil {
+			t.Fatalf("Got error while creating value writer %v", err)
+		}
+		enc, err := bson.NewEncoder(vw)
+		if err != nil {
+			t.Fatalf("Got error while creating encoder %v", err)
+		}
+		if err = enc.SetRegistry(reg); err != nil {
+			t.Fatalf("Got error while setting registry %v", err)
+		}
+		if err = enc.Encode(&data); err != nil {
+			t.Fatalf("Got error while encoding %v", err)
+		}
+
+		doc := bson.Raw(buf.Bytes())
+		for _, key := range []string{"_id", "parent_id", "reviewer_id"} {
+			if doc.Lookup(key).Type != expectedType {
+				t.Fatalf("Field %s was stored as %s instead of %s", key, doc.Lookup(key).Type, expectedType)
+			}
+		}
+		if doc.Lookup("tags").Type != bsontype.Array || doc.Lookup("tags", "0").Type != expectedType {
+			t.Fatalf("Slice was not stored as an array of %s", expectedType)
+		}
+		if doc.Lookup("owner_id").Type != bsontype.Null || doc.Lookup("editor_id").Type != bsontype.Null ||
+			doc.Lookup("links").Type != bsontype.Null {
+			t.Fatalf("Nil values were not stored as null")
+		}
+		if repr == BSONString && doc.Lookup("_id").StringValue() != id.String() {
+			t.Fatalf("Original ID (%s) did not match with the stored string %s", id.String(), doc.Lookup("_id"))
+		}
+		if subtype, _, ok := doc.Lookup("_id").BinaryOK(); ok && repr == BSONBinaryGeneric && subtype != bsontype.BinaryGeneric {
+			t.Fatalf("Was expecting generic binary subtype, got 0x%02x", subtype)
+		}
+
+		dec, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(doc))
+		if err != nil {
+			t.Fatalf("Got error while creating decoder %v", err)
+		}
+		if err = dec.SetRegistry(reg); err != nil {
+			t.Fatalf("Got error while setting registry %v", err)
+		}
+		var result IdTestStruct
+		if err = dec.Decode(&result); err != nil {
+			t.Fatalf("Got error while decoding %v", err)
+		}
+		if result.ID != id || result.ParentID == nil || *result.ParentID != parent || result.OwnerID != nil ||
+			result.ReviewerID != data.ReviewerID || result.EditorID.Valid ||
+			len(result.Tags) != 2 || result.Tags[0] != data.Tags[0] || result.Tags[1] != data.Tags[1] || result.Links != nil {
+			t.Fatalf("Decoded value %+v did not match with original value %+v", result, data)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+)
+
+func TestID_MarshalBSONValue(t *testing.T) {
+	type IdTestStruct struct {
+		ID ID `bson:"_id"`
+	}
+	id := NewID()
+	doc, err := bson.Marshal(&IdTestStruct{ID: id})
+	if err != nil {
+		t.Fatalf("Got error while marshaling to BSON %v", err)
+	}
+	subtype, data, ok := bson.Raw(doc).Lookup("_id").BinaryOK()
+	if !ok || subtype != bsontype.BinaryUUID || ID(data) != id {
+		t.Fatalf("Original ID (%s) was not stored as UUID binary: subtype 0x%02x %v", id.String(), subtype, data)
+	}
+
+	var result IdTestStruct
+	if err = bson.Unmarshal(doc, &result); err != nil {
+		t.Fatalf("Got error while unmarshaling BSON %v", err)
+	}
+	if result.ID != id {
+		t.Fatalf("Original ID (%s) did not match with the ID from BSON %s", id.String(), result.ID.String())
+	}
+}
+
+func TestID_UnmarshalBSONValue(t *testing.T) {
+	type IdTestStruct struct {
+		ID ID `bson:"_id"`
+	}
+	id := NewID()
+	docs := []bson.M{
+		{"_id": [16]byte(id)},
+		{"_id": primitive.Binary{Subtype: bsontype.BinaryUUID, Data: id[:]}},
+		{"_id": nil},
+		{"_id": id.String()},
+		{"_id": primitive.Binary{Subtype: bsontype.BinaryUUID, Data: id[:15]}},
+		{"_id": primitive.Binary{Subtype: bsontype.BinaryMD5, Data: id[:]}},
+		{"_id": int32(1)},
+		{"_id": "wrong"},
+	}
+	idVals := []ID{id, id, NilID, id, NilID, NilID, NilID, NilID}
+	for index, doc := range docs {
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			t.Fatalf("Got error while marshaling to BSON %v", err)
+		}
+		result := IdTestStruct{ID: NotNullNilID}
+		err = bson.Unmarshal(raw, &result)
+		if index < 4 {
+			if err != nil {
+				t.Fatalf("Got error while unmarshaling document %d: %v", index, err)
+			}
+			if result.ID != idVals[index] {
+				t.Fatalf("Original ID (%s) did not match with the ID from BSON %s %d", idVals[index].String(), result.ID.String(), index)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("Was expecting error for document %d, but there was no error", index)
+		}
+		if index == 4 && !errors.Is(err, ulid.ErrDataSize) {
+			t.Fatalf("Was expecting data size error, got %v", err)
+		}
+	}
+}
+
+func TestStringID_MarshalBSONValue(t *testing.T) {
+	type IdTestStruct struct {
+		ID StringID `bson:"_id"`
+	}
+	id := NewID()
+	doc, err := bson.Marshal(&IdTestStruct{ID: StringID(id)})
+	if err != nil {
+		t.Fatalf("Got error while marshaling to BSON %v", err)
+	}
+	if str, ok := bson.Raw(doc).Lookup("_id").StringValueOK(); !ok || str != id.String() {
+		t.Fatalf("Original ID (%s) was not stored as string: %v", id.String(), bson.Raw(doc).Lookup("_id"))
+	}
+
+	for _, val := range []interface{}{id.String(), [16]byte(id), id} {
+		raw, err := bson.Marshal(bson.M{"_id": val})
+		if err != nil {
+			t.Fatalf("Got error while marshaling to BSON %v", err)
+		}
+		var result IdTestStruct
+		if err = bson.Unmarshal(raw, &result); err != nil {
+			t.Fatalf("Got error while unmarshaling BSON %v", err)
+		}
+		if result.ID.ID() != id {
+			t.Fatalf("Original ID (%s) did not match with the ID from BSON %s", id.String(), result.ID.String())
+		}
+	}
+}
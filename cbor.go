@@ -0,0 +1,44 @@
+package idx
+
+import (
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// cborBytes16 is the header of a 16 byte CBOR byte string (major type 2).
+	cborBytes16 = 0x40 | 16
+	// cborText8 is the header of a CBOR text string (major type 3) with a one byte length.
+	cborText8 = 0x78
+	// cborTag8 is the header of a CBOR tag (major type 6) with a one byte tag number.
+	cborTag8 = 0xd8
+	// cborTagUUID is the tag number registered for UUIDs.
+	cborTagUUID   = 37
+	cborNull      = 0xf6
+	cborUndefined = 0xf7
+)
+
+// MarshalCBOR encodes the IDX as a 16-byte CBOR byte string. It implements the cbor.Marshaler interface of
+// github.com/fxamacker/cbor without depending on it.
+func (id ID) MarshalCBOR() ([]byte, error) {
+	b := make([]byte, 1, 1+len(id))
+	b[0] = cborBytes16
+	return append(b, id[:]...), nil
+}
+
+// UnmarshalCBOR decodes a 16-byte CBOR byte string, optionally tagged as a UUID (tag 37). ULID text strings
+// are accepted as well, and null or undefined decode as NilID.
+func (id *ID) UnmarshalCBOR(b []byte) error {
+	if len(b) > 2 && b[0] == cborTag8 && b[1] == cborTagUUID {
+		b = b[2:]
+	}
+	switch {
+	case len(b) == 1 && (b[0] == cborNull || b[0] == cborUndefined):
+		*id = NilID
+		return nil
+	case len(b) == 1+len(id) && b[0] == cborBytes16:
+		return id.UnmarshalBinary(b[1:])
+	case len(b) == 2+ulid.EncodedSize && b[0] == cborText8 && b[1] == ulid.EncodedSize:
+		return id.UnmarshalText(b[2:])
+	}
+	return ulid.ErrDataSize
+}
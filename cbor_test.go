@@ -0,0 +1,70 @@
+package idx
+
+import (
+	"errors"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/oklog/ulid/v2"
+	"testing"
+)
+
+func TestID_MarshalCBOR(t *testing.T) {
+	type IdTestStruct struct {
+		ID    ID   `cbor:"id"`
+		FkID  *ID  `cbor:"fk_id"`
+		Links []ID `cbor:"links"`
+	}
+	id, fk := NewID(), NewID()
+	data := IdTestStruct{ID: id, FkID: &fk, Links: []ID{NewID()}}
+	b, err := cbor.Marshal(&data)
+	if err != nil {
+		t.Fatalf("Got error while marshaling to CBOR %v", err)
+	}
+	var result IdTestStruct
+	if err = cbor.Unmarshal(b, &result); err != nil {
+		t.Fatalf("Got error while unmarshaling CBOR %v", err)
+	}
+	if result.ID != id || result.FkID == nil || *result.FkID != fk || len(result.Links) != 1 || result.Links[0] != data.Links[0] {
+		t.Fatalf("Decoded value %+v did not match with original value %+v", result, data)
+	}
+
+	single, err := cbor.Marshal(id)
+	if err != nil {
+		t.Fatalf("Got error while marshaling to CBOR %v", err)
+	}
+	if len(single) != 17 || single[0] != 0x50 || ID(single[1:]) != id {
+		t.Fatalf("ID was not encoded as 16-byte byte string: %v", single)
+	}
+}
+
+func TestID_UnmarshalCBOR(t *testing.T) {
+	id := NewID()
+	tagged, err := cbor.Marshal(cbor.Tag{Number: 37, Content: id[:]})
+	if err != nil {
+		t.Fatalf("Got error while marshaling to CBOR %v", err)
+	}
+	str, err := cbor.Marshal(id.String())
+	if err != nil {
+		t.Fatalf("Got error while marshaling to CBOR %v", err)
+	}
+	for _, b := range [][]byte{tagged, str} {
+		var unm ID
+		if err = cbor.Unmarshal(b, &unm); err != nil {
+			t.Fatalf("Got error while unmarshaling CBOR %v", err)
+		}
+		if unm != id {
+			t.Fatalf("Original ID (%s) did not match with the ID from CBOR %s", id.String(), unm.String())
+		}
+	}
+
+	unm := id
+	if err = unm.UnmarshalCBOR([]byte{0xf6}); err != nil || unm != NilID {
+		t.Fatalf("Was expecting NilID without error, got %s %v", unm.String(), err)
+	}
+	invalid, err := cbor.Marshal(id[:15])
+	if err != nil {
+		t.Fatalf("Got error while marshaling to CBOR %v", err)
+	}
+	if err = unm.UnmarshalCBOR(invalid); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+)
+
+// CheckedEncodedSize is the length of the checksummed string form of an ID.
+const CheckedEncodedSize = ulid.EncodedSize + 2
+
+// ErrChecksum is returned when the check characters of a checksummed ID do not match its payload.
+var ErrChecksum = errors.New("idx: checksum mismatch")
+
+// CheckedString returns the ULID string followed by two check characters. The check characters
+// hold a CRC-10 over the 16 bytes of the ID, which detects every single character substitution
+// and every transposition of adjacent characters.
+func (id ID) CheckedString() string {
+	dst := make([]byte, CheckedEncodedSize)
+	_ = ulid.ULID(id).MarshalTextTo(dst[:ulid.EncodedSize])
+	sum := crc10(id)
+	dst[26] = ulid.Encoding[sum>>5]
+	dst[27] = ulid.Encoding[sum&0x1F]
+	return string(dst)
+}
+
+// FromCheckedString parses a string produced by CheckedString, verifying the check characters.
+// ErrChecksum is returned when they do not match the decoded ID.
+func FromCheckedString(val string) (ID, error) {
+	if len(val) != CheckedEncodedSize {
+		return NilID, ulid.ErrDataSize
+	}
+	id, err := FromString(val[:ulid.EncodedSize])
+	if err != nil {
+		return NilID, err
+	}
+	hi, lo := dec[val[26]], dec[val[27]]
+	if hi == 0xFF || lo == 0xFF {
+		return NilID, ulid.ErrInvalidCharacters
+	}
+	if uint16(hi)<<5|uint16(lo) != crc10(id) {
+		return NilID, ErrChecksum
+	}
+	return id, nil
+}
+
+// crc10 computes the CRC-10/ATM checksum (polynomial x^10+x^9+x^5+x^4+x+1) of the ID bytes.
+// Every burst error of up to 10 bits is detected, which covers any change to one or two
+// adjacent base32 characters.
+func crc10(id ID) uint16 {
+	const poly = 0x233
+	var crc uint16
+	for _, b := range id {
+		crc ^= uint16(b) << 2
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x400 != 0 {
+				crc ^= poly
+			}
+		}
+	}
+	return crc & 0x3FF
+}
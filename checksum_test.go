@@ -0,0 +1,63 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestID_CheckedString(t *testing.T) {
+	id := NewID()
+	checked := id.CheckedString()
+	if len(checked) != CheckedEncodedSize || !strings.HasPrefix(checked, id.String()) {
+		t.Fatalf("Checked string (%s) does not start with the ID (%s)", checked, id.String())
+	}
+	for _, val := range []string{checked, strings.ToLower(checked)} {
+		idFromStr, err := FromCheckedString(val)
+		if err != nil {
+			t.Fatalf("Got error while parsing checked string %v", err)
+		}
+		if idFromStr != id {
+			t.Fatalf("Original ID (%s) did not match with parsed ID (%s)", id.String(), idFromStr.String())
+		}
+	}
+}
+
+func TestFromCheckedString(t *testing.T) {
+	id := NewID()
+	checked := id.CheckedString()
+
+	// Every single character substitution must be rejected
+	for i := 0; i < len(checked); i++ {
+		for _, c := range ulid.Encoding {
+			if byte(c) == checked[i] {
+				continue
+			}
+			typo := checked[:i] + string(c) + checked[i+1:]
+			if _, err := FromCheckedString(typo); err == nil {
+				t.Fatalf("Was expecting error for %s (original %s), but there was no error", typo, checked)
+			}
+		}
+	}
+	// Adjacent transpositions must be rejected as well
+	for i := 0; i < len(checked)-1; i++ {
+		if checked[i] == checked[i+1] {
+			continue
+		}
+		swapped := checked[:i] + string(checked[i+1]) + string(checked[i]) + checked[i+2:]
+		if _, err := FromCheckedString(swapped); err == nil {
+			t.Fatalf("Was expecting error for %s (original %s), but there was no error", swapped, checked)
+		}
+	}
+
+	invalid := map[string]error{
+		id.String():         ulid.ErrDataSize,
+		checked[:26] + "U0": ulid.ErrInvalidCharacters,
+	}
+	for val, expected := range invalid {
+		if _, err := FromCheckedString(val); !errors.Is(err, expected) {
+			t.Fatalf("Error did not match expectation %v : %v", err, expected)
+		}
+	}
+}
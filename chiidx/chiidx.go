@@ -0,0 +1,23 @@
+// Package chiidx binds chi (https://pkg.go.dev/github.com/go-chi/chi/v5) URL parameters to idx.ID values.
+package chiidx
+
+import (
+	"errors"
+	"github.com/go-chi/chi/v5"
+	"github.com/ieshan/idx"
+	"net/http"
+)
+
+// Param parses the URL parameter name of r. When it is missing or invalid, Param responds with status 400
+// and the message of the *idx.ParamError as plain text, and returns false, so the handler only has to
+// return.
+func Param(w http.ResponseWriter, r *http.Request, name string) (idx.ID, bool) {
+	id, err := idx.ParseParam(name, chi.URLParam(r, name))
+	if err != nil {
+		var paramErr *idx.ParamError
+		errors.As(err, &paramErr)
+		http.Error(w, err.Error(), paramErr.StatusCode())
+		return idx.NilID, false
+	}
+	return id, true
+}
@@ -0,0 +1,36 @@
+package chiidx
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/ieshan/idx"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParam(t *testing.T) {
+	var got idx.ID
+	router := chi.NewRouter()
+	router.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, ok := Param(w, r, "id")
+		if !ok {
+			return
+		}
+		got = id
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	id := idx.NewID()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/"+id.String(), nil))
+	if w.Code != http.StatusNoContent || got != id {
+		t.Fatalf("Parsed ID (%s) did not match with %s, status %d", got.String(), id.String(), w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/invalid", nil))
+	expected := "idx: path parameter id: \"invalid\" is not a valid id\n"
+	if w.Code != http.StatusBadRequest || w.Body.String() != expected {
+		t.Fatalf("Was expecting status 400 and %q, got %d and %q", expected, w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,95 @@
+// Command idx provides maintenance tools for databases holding idx IDs.
+//
+// The audit subcommand streams an ID column and writes a JSON line per malformed, NilID or duplicated
+// value, as described by idx.AuditFinding, followed by a summary on stderr:
+//
+//	idx audit -driver pgx -dsn "$DATABASE_URL" -table orders -key pk -column id > findings.jsonl
+//	idx audit -driver mongodb -dsn "$MONGO_URI" -database shop -table orders -column _id
+//
+// The supported drivers are mysql, pgx, sqlite3 and mongodb.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/ieshan/idx"
+	"github.com/ieshan/idx/mongoidx"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/mattn/go-sqlite3"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the subcommand in args and returns the exit status.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "audit" {
+		fmt.Fprintln(stderr, "usage: idx audit [flags]")
+		return 2
+	}
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	driver := fs.String("driver", "", "database driver: mysql, pgx, sqlite3 or mongodb")
+	dsn := fs.String("dsn", "", "data source name or MongoDB connection string")
+	database := fs.String("database", "", "MongoDB database")
+	table := fs.String("table", "", "table or collection to audit")
+	key := fs.String("key", "", "column identifying the rows, defaults to the ID column")
+	column := fs.String("column", "id", "ID column or field")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *driver == "" || *table == "" {
+		fmt.Fprintln(stderr, "idx audit: -driver and -table are required")
+		return 2
+	}
+	if *key == "" {
+		*key = *column
+	}
+
+	enc := json.NewEncoder(stdout)
+	var findings int64
+	report := func(f idx.AuditFinding) error {
+		findings++
+		return enc.Encode(f)
+	}
+	var scanned int64
+	var err error
+	if *driver == "mongodb" {
+		scanned, err = auditCollection(ctx, *dsn, *database, *table, *column, report)
+	} else {
+		scanned, err = auditTable(ctx, *driver, *dsn, *table, *key, *column, report)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "idx audit: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stderr, "scanned %d rows, %d findings\n", scanned, findings)
+	return 0
+}
+
+func auditTable(ctx context.Context, driver, dsn, table, key, column string, report func(idx.AuditFinding) error) (int64, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	return idx.AuditTable(ctx, db, table, key, column, report)
+}
+
+func auditCollection(ctx context.Context, uri, database, collection, field string, report func(idx.AuditFinding) error) (int64, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = client.Disconnect(ctx) }()
+	return mongoidx.AuditCollection(ctx, client.Database(database).Collection(collection), field, report)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/ieshan/idx"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunAudit(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "audit.db")
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	defer db.Close()
+	if _, err = db.Exec("CREATE TABLE orders (id TEXT PRIMARY KEY)"); err != nil {
+		t.Fatalf("Got error while creating table %v", err)
+	}
+	for _, value := range []string{idx.NewID().String(), idx.NilID.String(), "invalid"} {
+		if _, err = db.Exec("INSERT INTO orders (id) VALUES (?)", value); err != nil {
+			t.Fatalf("Got error while inserting row %v", err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"audit", "-driver", "sqlite3", "-dsn", dsn, "-table", "orders"}
+	if status := run(context.Background(), args, &stdout, &stderr); status != 0 {
+		t.Fatalf("Was expecting exit status 0, got %d: %s", status, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Was expecting 2 findings, got %q", stdout.String())
+	}
+	var finding idx.AuditFinding
+	if err = json.Unmarshal([]byte(lines[1]), &finding); err != nil || finding.Issue != idx.AuditWrongLength || finding.Key != "invalid" {
+		t.Fatalf("Unexpected finding %s: %v", lines[1], err)
+	}
+	if stderr.String() != "scanned 3 rows, 2 findings\n" {
+		t.Fatalf("Unexpected summary %q", stderr.String())
+	}
+
+	stderr.Reset()
+	if status := run(context.Background(), []string{"audit", "-driver", "sqlite3"}, &stdout, &stderr); status != 2 {
+		t.Fatalf("Was expecting exit status 2, got %d", status)
+	}
+	if status := run(context.Background(), nil, &stdout, &stderr); status != 2 {
+		t.Fatalf("Was expecting exit status 2, got %d", status)
+	}
+}
@@ -0,0 +1,61 @@
+package idx
+
+import (
+	"context"
+)
+
+// ContextKey identifies an ID carried by a context.Context, so handler and repository layers can read it
+// without threading it through every call. RequestIDKey and TenantIDKey cover the common cases and
+// NewContextKey defines further labels.
+type ContextKey struct {
+	name string
+}
+
+var (
+	// RequestIDKey carries the ID of the current request, see NewContext and FromContext.
+	RequestIDKey = NewContextKey("request_id")
+	// TenantIDKey carries the ID of the current tenant, see NewTenantContext and TenantFromContext.
+	TenantIDKey = NewContextKey("tenant_id")
+)
+
+// NewContextKey returns a key for an ID labelled name. Every call returns a distinct key, even for equal
+// names, so packages cannot overwrite each other's IDs.
+func NewContextKey(name string) *ContextKey {
+	return &ContextKey{name: name}
+}
+
+// String returns the label of the key.
+func (k *ContextKey) String() string {
+	return k.name
+}
+
+// NewContext returns a copy of ctx carrying id under the key.
+func (k *ContextKey) NewContext(ctx context.Context, id ID) context.Context {
+	return context.WithValue(ctx, k, id)
+}
+
+// FromContext returns the ID carried by ctx under the key, and whether there was one.
+func (k *ContextKey) FromContext(ctx context.Context) (ID, bool) {
+	id, ok := ctx.Value(k).(ID)
+	return id, ok
+}
+
+// NewContext returns a copy of ctx carrying id as the request ID.
+func NewContext(ctx context.Context, id ID) context.Context {
+	return RequestIDKey.NewContext(ctx, id)
+}
+
+// FromContext returns the request ID carried by ctx, and whether there was one.
+func FromContext(ctx context.Context) (ID, bool) {
+	return RequestIDKey.FromContext(ctx)
+}
+
+// NewTenantContext returns a copy of ctx carrying id as the tenant ID.
+func NewTenantContext(ctx context.Context, id ID) context.Context {
+	return TenantIDKey.NewContext(ctx, id)
+}
+
+// TenantFromContext returns the tenant ID carried by ctx, and whether there was one.
+func TenantFromContext(ctx context.Context) (ID, bool) {
+	return TenantIDKey.FromContext(ctx)
+}
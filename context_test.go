@@ -0,0 +1,33 @@
+package idx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	requestID, tenantID, orderID := NewID(), NewID(), NewID()
+	orderKey := NewContextKey("order_id")
+	ctx := NewContext(context.Background(), requestID)
+	ctx = NewTenantContext(ctx, tenantID)
+	ctx = orderKey.NewContext(ctx, orderID)
+
+	if id, ok := FromContext(ctx); !ok || id != requestID {
+		t.Fatalf("Request ID (%s) did not match with %s", id.String(), requestID.String())
+	}
+	if id, ok := TenantFromContext(ctx); !ok || id != tenantID {
+		t.Fatalf("Tenant ID (%s) did not match with %s", id.String(), tenantID.String())
+	}
+	if id, ok := orderKey.FromContext(ctx); !ok || id != orderID {
+		t.Fatalf("Order ID (%s) did not match with %s", id.String(), orderID.String())
+	}
+	if _, ok := NewContextKey("order_id").FromContext(ctx); ok {
+		t.Fatalf("Was expecting keys with equal names to be distinct")
+	}
+	if id, ok := FromContext(context.Background()); ok || id != NilID {
+		t.Fatalf("Was expecting no request ID, got %s", id.String())
+	}
+	if orderKey.String() != "order_id" {
+		t.Fatalf("Unexpected key name %s", orderKey.String())
+	}
+}
@@ -0,0 +1,193 @@
+package idx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UUIDConversion copies the UUID strings stored in a text column into a binary column, batch by batch,
+// so large tables can move to the 16-byte format without locking them for the whole migration:
+//
+//	ALTER TABLE orders ADD COLUMN id_bin BINARY(16) NULL;
+//
+//	conv := idx.UUIDConversion{Table: "orders", Key: "pk", Source: "id", Target: "id_bin",
+//		Progress: func(p idx.ConversionProgress) { log.Printf("converted %d rows", p.Converted) }}
+//	err := conv.Run(ctx, db)
+//
+// Only rows whose target is NULL are converted, so an interrupted run can simply be started again.
+// Setting After to the last reported key also skips the rows converted before. Swapping the columns
+// once the run completes is left to the schema migration.
+type UUIDConversion struct {
+	// Table is the table to convert.
+	Table string
+	// Key is a unique column the batches are ordered by, usually the primary key.
+	Key string
+	// Source is the column holding the UUID strings. NULL values are left alone.
+	Source string
+	// Target is the binary column receiving the IDs.
+	Target string
+	// Dialect selects the SQL syntax: "postgres" uses $1 placeholders, "sqlserver" uses @p1 and TOP, and
+	// the others use ? and LIMIT.
+	Dialect string
+	// BatchSize is the number of rows updated per transaction, 1000 when not set.
+	BatchSize int
+	// After resumes the conversion after the given key value, as reported by Progress.
+	After interface{}
+	// Progress is called after every committed batch.
+	Progress func(ConversionProgress)
+}
+
+// ConversionProgress reports the state of a UUIDConversion, CreatedAtBackfill or mongoidx.CreatedAtBackfill.
+type ConversionProgress struct {
+	// Converted is the number of rows converted by this run.
+	Converted int64
+	// Last is the key of the last converted row, to be used as After when resuming.
+	Last interface{}
+}
+
+// Run converts the rows, returning at the first invalid UUID or database error. Batches committed
+// before the error are kept.
+func (c UUIDConversion) Run(ctx context.Context, db *sql.DB) error {
+	return batchUpdate{
+		table: c.Table, key: c.Key, source: c.Source, target: c.Target, dialect: c.Dialect,
+		size: c.BatchSize, after: c.After, progress: c.Progress,
+		convert: func(key, source interface{}) (interface{}, error) {
+			id, err := FromHex(asString(source))
+			if err != nil {
+				return nil, fmt.Errorf("idx: converting %s %v: %w", c.Key, key, err)
+			}
+			return id[:], nil
+		},
+	}.run(ctx, db)
+}
+
+// asString returns the text of a value scanned from a text column, which drivers report as a string or
+// a byte slice.
+func asString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// batchUpdate fills the NULL target column of a table from its source column, batch by batch in key
+// order, for UUIDConversion and CreatedAtBackfill.
+type batchUpdate struct {
+	table, key, source, target, dialect string
+	size                                int
+	after                               interface{}
+	progress                            func(ConversionProgress)
+	// convert returns the target value of the row with the given key and source value.
+	convert func(key, source interface{}) (interface{}, error)
+}
+
+func (u batchUpdate) run(ctx context.Context, db *sql.DB) error {
+	if u.size <= 0 {
+		u.size = 1000
+	}
+	progress := ConversionProgress{Last: u.after}
+	for {
+		keys, values, err := u.batch(ctx, db, progress.Last)
+		if err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+		if err = u.update(ctx, db, keys, values); err != nil {
+			return err
+		}
+		progress.Converted += int64(len(keys))
+		progress.Last = keys[len(keys)-1]
+		if u.progress != nil {
+			u.progress(progress)
+		}
+		if len(keys) < u.size {
+			return nil
+		}
+	}
+}
+
+// batch reads the next rows to update after the key last and converts their source values.
+func (u batchUpdate) batch(ctx context.Context, db *sql.DB, last interface{}) ([]interface{}, []interface{}, error) {
+	var args []interface{}
+	if last != nil {
+		args = append(args, last)
+	}
+	rows, err := db.QueryContext(ctx, u.selectQuery(last != nil), args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	var keys, values []interface{}
+	for rows.Next() {
+		var key, source interface{}
+		if err = rows.Scan(&key, &source); err != nil {
+			return nil, nil, err
+		}
+		value, err := u.convert(key, source)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys, values = append(keys, key), append(values, value)
+	}
+	return keys, values, rows.Err()
+}
+
+// selectQuery returns the query reading a batch, with a placeholder for the last key when resuming.
+// SQL Server has no LIMIT clause, so TOP is used there.
+func (u batchUpdate) selectQuery(resume bool) string {
+	var query strings.Builder
+	query.WriteString("SELECT ")
+	if u.dialect == "sqlserver" {
+		fmt.Fprintf(&query, "TOP %d ", u.size)
+	}
+	fmt.Fprintf(&query, "%s, %s FROM %s WHERE %s IS NULL AND %s IS NOT NULL", u.key, u.source, u.table, u.target, u.source)
+	if resume {
+		fmt.Fprintf(&query, " AND %s > %s", u.key, sqlPlaceholder(u.dialect, 1))
+	}
+	fmt.Fprintf(&query, " ORDER BY %s", u.key)
+	if u.dialect != "sqlserver" {
+		fmt.Fprintf(&query, " LIMIT %d", u.size)
+	}
+	return query.String()
+}
+
+// update stores values in the target column of the rows with the given keys, in one transaction.
+func (u batchUpdate) update(ctx context.Context, db *sql.DB, keys, values []interface{}) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	query := fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s", u.table, u.target, sqlPlaceholder(u.dialect, 1), u.key, sqlPlaceholder(u.dialect, 2))
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for i, key := range keys {
+		if _, err = stmt.ExecContext(ctx, values[i], key); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// sqlPlaceholder returns the nth placeholder of dialect: $n for "postgres", @pn for "sqlserver" and ? for
+// the others.
+func sqlPlaceholder(dialect string, n int) string {
+	switch dialect {
+	case "postgres":
+		return "$" + strconv.Itoa(n)
+	case "sqlserver":
+		return "@p" + strconv.Itoa(n)
+	}
+	return "?"
+}
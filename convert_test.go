@@ -0,0 +1,94 @@
+package idx
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"testing"
+)
+
+func TestUUIDConversion(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	if _, err = db.Exec("CREATE TABLE orders (pk INTEGER PRIMARY KEY, id TEXT, id_bin BLOB)"); err != nil {
+		t.Fatalf("Got error while creating table %v", err)
+	}
+	ids := make([]ID, 25)
+	for i := range ids {
+		ids[i] = NewID()
+		if _, err = db.Exec("INSERT INTO orders (pk, id) VALUES (?, ?)", i+1, ids[i].UUIDString()); err != nil {
+			t.Fatalf("Got error while inserting row %v", err)
+		}
+	}
+	if _, err = db.Exec("INSERT INTO orders (pk, id) VALUES (100, NULL)"); err != nil {
+		t.Fatalf("Got error while inserting row %v", err)
+	}
+
+	var reports []ConversionProgress
+	conv := UUIDConversion{Table: "orders", Key: "pk", Source: "id", Target: "id_bin", BatchSize: 10,
+		Progress: func(p ConversionProgress) { reports = append(reports, p) }}
+	if err = conv.Run(context.Background(), db); err != nil {
+		t.Fatalf("Got error while converting %v", err)
+	}
+	if len(reports) != 3 || reports[2].Converted != 25 || reports[2].Last != int64(25) {
+		t.Fatalf("Unexpected progress reports %+v", reports)
+	}
+	rows, err := db.Query("SELECT pk, id_bin FROM orders WHERE id IS NOT NULL ORDER BY pk")
+	if err != nil {
+		t.Fatalf("Got error while querying %v", err)
+	}
+	for rows.Next() {
+		var pk int
+		var id ID
+		if err = rows.Scan(&pk, &id); err != nil {
+			t.Fatalf("Got error while scanning %v", err)
+		}
+		if id != ids[pk-1] {
+			t.Fatalf("Original ID (%s) did not match with the converted ID %s", ids[pk-1].String(), id.String())
+		}
+	}
+	if err = rows.Close(); err != nil {
+		t.Fatalf("Got error while closing rows %v", err)
+	}
+
+	// A second run finds nothing left to convert.
+	reports = nil
+	if err = conv.Run(context.Background(), db); err != nil || len(reports) != 0 {
+		t.Fatalf("Was expecting nothing to convert, got %+v: %v", reports, err)
+	}
+
+	// Resuming after a key skips the rows before it.
+	if _, err = db.Exec("UPDATE orders SET id_bin = NULL WHERE pk IN (5, 22)"); err != nil {
+		t.Fatalf("Got error while resetting rows %v", err)
+	}
+	conv.After = int64(20)
+	if err = conv.Run(context.Background(), db); err != nil || len(reports) != 1 || reports[0].Converted != 1 {
+		t.Fatalf("Was expecting one row to convert, got %+v: %v", reports, err)
+	}
+	conv.After = nil
+
+	if _, err = db.Exec("INSERT INTO orders (pk, id) VALUES (200, 'not-a-uuid')"); err != nil {
+		t.Fatalf("Got error while inserting row %v", err)
+	}
+	if err = conv.Run(context.Background(), db); err == nil {
+		t.Fatalf("Was expecting error for invalid UUID")
+	}
+}
+
+func TestBatchUpdate_SelectQuery(t *testing.T) {
+	expected := map[string]string{
+		"mysql":     "SELECT pk, id FROM orders WHERE id_bin IS NULL AND id IS NOT NULL AND pk > ? ORDER BY pk LIMIT 50",
+		"postgres":  "SELECT pk, id FROM orders WHERE id_bin IS NULL AND id IS NOT NULL AND pk > $1 ORDER BY pk LIMIT 50",
+		"sqlserver": "SELECT TOP 50 pk, id FROM orders WHERE id_bin IS NULL AND id IS NOT NULL AND pk > @p1 ORDER BY pk",
+	}
+	for dialect, query := range expected {
+		u := batchUpdate{table: "orders", key: "pk", source: "id", target: "id_bin", dialect: dialect, size: 50}
+		if result := u.selectQuery(true); result != query {
+			t.Fatalf("Query %q for %s did not match with %q", result, dialect, query)
+		}
+	}
+}
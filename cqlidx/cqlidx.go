@@ -0,0 +1,75 @@
+// Package cqlidx binds idx.ID values to Cassandra and ScyllaDB columns with gocql
+// (https://pkg.go.dev/github.com/gocql/gocql), so call sites do not convert IDs to gocql.UUID.
+package cqlidx
+
+import (
+	"fmt"
+	"github.com/gocql/gocql"
+	"github.com/ieshan/idx"
+)
+
+// UUID is an idx.ID implementing gocql.Marshaler and gocql.Unmarshaler. It is used as a struct field type,
+// or converted at the call site:
+//
+//	err := session.Query("INSERT INTO orders (id, amount) VALUES (?, ?)", cqlidx.UUID(id), amount).Exec()
+//	err = session.Query("SELECT id FROM orders WHERE ...").Scan(cqlidx.Dest(&id))
+type UUID idx.ID
+
+// Dest returns id as a *UUID, to be passed to Scan.
+func Dest(id *idx.ID) *UUID {
+	return (*UUID)(id)
+}
+
+// ID returns the UUID as an idx.ID.
+func (u UUID) ID() idx.ID {
+	return idx.ID(u)
+}
+
+func (u UUID) String() string {
+	return idx.ID(u).String()
+}
+
+// MarshalCQL implements gocql.Marshaler. IDs are written as their 16 bytes to uuid and blob columns and as
+// ULID strings to text, varchar and ascii columns. timeuuid columns only accept version 1 UUIDs, which IDs
+// are not, so they are rejected. NilID is written as null.
+func (u UUID) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	id := idx.ID(u)
+	if id == idx.NilID {
+		return nil, nil
+	}
+	switch info.Type() {
+	case gocql.TypeUUID, gocql.TypeBlob:
+		return id[:], nil
+	case gocql.TypeVarchar, gocql.TypeText, gocql.TypeAscii:
+		return id.AppendString(nil), nil
+	}
+	return nil, fmt.Errorf("idx: cannot marshal ID into CQL type %s", info.Type())
+}
+
+// UnmarshalCQL implements gocql.Unmarshaler. uuid, timeuuid and blob values must hold 16 bytes and text
+// values a ULID string. null is read as NilID.
+func (u *UUID) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	id := (*idx.ID)(u)
+	if len(data) == 0 {
+		*id = idx.NilID
+		return nil
+	}
+	switch info.Type() {
+	case gocql.TypeUUID, gocql.TypeTimeUUID, gocql.TypeBlob:
+		return id.UnmarshalBinary(data)
+	case gocql.TypeVarchar, gocql.TypeText, gocql.TypeAscii:
+		return id.UnmarshalText(data)
+	}
+	return fmt.Errorf("idx: cannot unmarshal CQL type %s into ID", info.Type())
+}
+
+// ToGocqlUUID returns id as a gocql.UUID, the type the driver decodes uuid columns to for untyped
+// destinations.
+func ToGocqlUUID(id idx.ID) gocql.UUID {
+	return gocql.UUID(id)
+}
+
+// FromGocqlUUID returns the ID held by a gocql.UUID.
+func FromGocqlUUID(u gocql.UUID) idx.ID {
+	return idx.ID(u)
+}
@@ -0,0 +1,56 @@
+package cqlidx
+
+import (
+	"errors"
+	"github.com/gocql/gocql"
+	"github.com/ieshan/idx"
+	"github.com/oklog/ulid/v2"
+	"testing"
+)
+
+func TestUUID_MarshalCQL(t *testing.T) {
+	id := idx.NewID()
+	for _, typ := range []gocql.Type{gocql.TypeUUID, gocql.TypeBlob, gocql.TypeText, gocql.TypeVarchar, gocql.TypeAscii} {
+		info := gocql.NewNativeType(4, typ, "")
+		b, err := gocql.Marshal(info, UUID(id))
+		if err != nil {
+			t.Fatalf("Got error while marshaling to %s %v", typ, err)
+		}
+		var result idx.ID
+		if err = gocql.Unmarshal(info, b, Dest(&result)); err != nil {
+			t.Fatalf("Got error while unmarshaling %s %v", typ, err)
+		}
+		if result != id {
+			t.Fatalf("Original ID (%s) did not match with unmarshaled ID (%s)", id.String(), result.String())
+		}
+
+		// The driver decodes uuid columns to gocql.UUID for untyped destinations.
+		if typ == gocql.TypeUUID {
+			var u gocql.UUID
+			if err = gocql.Unmarshal(info, b, &u); err != nil || FromGocqlUUID(u) != id || u != ToGocqlUUID(id) {
+				t.Fatalf("UUID %s did not match with %s: %v", u.String(), id.UUIDString(), err)
+			}
+		}
+	}
+
+	info := gocql.NewNativeType(4, gocql.TypeUUID, "")
+	if b, err := gocql.Marshal(info, UUID(idx.NilID)); err != nil || b != nil {
+		t.Fatalf("Was expecting null for NilID, got %v: %v", b, err)
+	}
+	result := UUID(id)
+	if err := gocql.Unmarshal(info, nil, &result); err != nil || result.ID() != idx.NilID {
+		t.Fatalf("Was expecting NilID for null, got %s: %v", result.String(), err)
+	}
+	if _, err := gocql.Marshal(gocql.NewNativeType(4, gocql.TypeTimeUUID, ""), UUID(id)); err == nil {
+		t.Fatalf("Was expecting error for timeuuid")
+	}
+	if _, err := gocql.Marshal(gocql.NewNativeType(4, gocql.TypeInt, ""), UUID(id)); err == nil {
+		t.Fatalf("Was expecting error for int")
+	}
+	if err := gocql.Unmarshal(gocql.NewNativeType(4, gocql.TypeBlob, ""), id[:15], &result); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+	if err := gocql.Unmarshal(gocql.NewNativeType(4, gocql.TypeInt, ""), id[:4], &result); err == nil {
+		t.Fatalf("Was expecting error for int")
+	}
+}
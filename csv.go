@@ -0,0 +1,130 @@
+package idx
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"io"
+)
+
+// MarshalCSV implements the csvutil.Marshaler interface (https://pkg.go.dev/github.com/jszwec/csvutil#Marshaler).
+// NilID is written as an empty field.
+func (id ID) MarshalCSV() ([]byte, error) {
+	if id == NilID {
+		return []byte{}, nil
+	}
+	return id.AppendString(make([]byte, 0, ulid.EncodedSize)), nil
+}
+
+// UnmarshalCSV implements the csvutil.Unmarshaler interface (https://pkg.go.dev/github.com/jszwec/csvutil#Unmarshaler).
+// Empty fields decode to NilID, and the hexadecimal and UUID forms are accepted besides ULID strings.
+func (id *ID) UnmarshalCSV(b []byte) error {
+	if len(b) == 0 {
+		*id = NilID
+		return nil
+	}
+	parsed, err := parseAny(string(b))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// CSVOption configures WriteCSVColumn and ReadCSVColumn.
+type CSVOption func(*csvConfig)
+
+type csvConfig struct {
+	header string
+	uuid   bool
+}
+
+// WithCSVHeader makes WriteCSVColumn write name as a header row, and ReadCSVColumn skip the header row and
+// read the column with that name.
+func WithCSVHeader(name string) CSVOption {
+	return func(c *csvConfig) {
+		c.header = name
+	}
+}
+
+// WithCSVUUID makes WriteCSVColumn write IDs in the UUID form, which spreadsheets handle more reliably.
+func WithCSVUUID() CSVOption {
+	return func(c *csvConfig) {
+		c.uuid = true
+	}
+}
+
+// WriteCSVColumn writes ids to w as a single column, one ID per record, and flushes w. NilID is written in
+// full rather than as an empty field, since csv.Reader skips blank lines.
+func WriteCSVColumn(w *csv.Writer, ids []ID, opts ...CSVOption) error {
+	c := newCSVConfig(opts)
+	if c.header != "" {
+		if err := w.Write([]string{c.header}); err != nil {
+			return err
+		}
+	}
+	record := make([]string, 1)
+	for _, id := range ids {
+		if c.uuid {
+			record[0] = id.UUIDString()
+		} else {
+			record[0] = id.String()
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// ReadCSVColumn reads the IDs in the given zero-based column of every record of r. When a header is
+// configured with WithCSVHeader the column is located by name instead. Empty fields are read as NilID and
+// parse errors report the line they occurred on.
+func ReadCSVColumn(r *csv.Reader, column int, opts ...CSVOption) ([]ID, error) {
+	c := newCSVConfig(opts)
+	if c.header != "" {
+		record, err := r.Read()
+		if err != nil {
+			return nil, err
+		}
+		column = -1
+		for i, name := range record {
+			if name == c.header {
+				column = i
+				break
+			}
+		}
+		if column < 0 {
+			return nil, fmt.Errorf("idx: csv column %q not found", c.header)
+		}
+	}
+	var ids []ID
+	for {
+		record, err := r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return ids, nil
+			}
+			return ids, err
+		}
+		line, _ := r.FieldPos(0)
+		if column >= len(record) {
+			return ids, fmt.Errorf("idx: csv line %d: missing column %d", line, column)
+		}
+		var id ID
+		if err = id.UnmarshalCSV([]byte(record[column])); err != nil {
+			return ids, fmt.Errorf("idx: csv line %d: %w", line, err)
+		}
+		ids = append(ids, id)
+	}
+}
+
+func newCSVConfig(opts []CSVOption) csvConfig {
+	var c csvConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
@@ -0,0 +1,99 @@
+package idx
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"github.com/jszwec/csvutil"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestID_MarshalCSV(t *testing.T) {
+	type IdTestStruct struct {
+		ID   ID  `csv:"id"`
+		FkID *ID `csv:"fk_id,omitempty"`
+		Ref  ID  `csv:"ref"`
+	}
+	id, fk := NewID(), NewID()
+	data := []IdTestStruct{{ID: id, FkID: &fk}}
+	b, err := csvutil.Marshal(data)
+	if err != nil {
+		t.Fatalf("Got error while marshaling to csv %v", err)
+	}
+	expected := "id,fk_id,ref\n" + id.String() + "," + fk.String() + ",\n"
+	if string(b) != expected {
+		t.Fatalf("Encoded csv %q did not match with %q", b, expected)
+	}
+	var result []IdTestStruct
+	if err = csvutil.Unmarshal(b, &result); err != nil {
+		t.Fatalf("Got error while unmarshaling csv %v", err)
+	}
+	if len(result) != 1 || result[0].ID != id || result[0].FkID == nil || *result[0].FkID != fk || result[0].Ref != NilID {
+		t.Fatalf("Decoded value %+v did not match with original value %+v", result, data)
+	}
+}
+
+func TestID_UnmarshalCSV(t *testing.T) {
+	id := NewID()
+	for _, val := range []string{id.String(), strings.ToLower(id.String()), id.Hex(), id.UUIDString()} {
+		var result ID
+		if err := result.UnmarshalCSV([]byte(val)); err != nil {
+			t.Fatalf("Got error while unmarshaling %s %v", val, err)
+		}
+		if result != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), result.String())
+		}
+	}
+	var result ID
+	if err := result.UnmarshalCSV([]byte("invalid")); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
+
+func TestWriteCSVColumn(t *testing.T) {
+	ids := []ID{NewID(), NilID, NewID()}
+	var buf bytes.Buffer
+	if err := WriteCSVColumn(csv.NewWriter(&buf), ids, WithCSVHeader("id"), WithCSVUUID()); err != nil {
+		t.Fatalf("Got error while writing csv %v", err)
+	}
+	expected := "id\n" + ids[0].UUIDString() + "\n" + NilID.UUIDString() + "\n" + ids[2].UUIDString() + "\n"
+	if buf.String() != expected {
+		t.Fatalf("Written csv %q did not match with %q", buf.String(), expected)
+	}
+	result, err := ReadCSVColumn(csv.NewReader(&buf), 0, WithCSVHeader("id"))
+	if err != nil {
+		t.Fatalf("Got error while reading csv %v", err)
+	}
+	if len(result) != len(ids) || result[0] != ids[0] || result[1] != NilID || result[2] != ids[2] {
+		t.Fatalf("Read IDs %v did not match with %v", result, ids)
+	}
+
+	buf.Reset()
+	if err = WriteCSVColumn(csv.NewWriter(&buf), ids[:1]); err != nil {
+		t.Fatalf("Got error while writing csv %v", err)
+	}
+	if buf.String() != ids[0].String()+"\n" {
+		t.Fatalf("Written csv %q did not use ULID form", buf.String())
+	}
+}
+
+func TestReadCSVColumn(t *testing.T) {
+	id := NewID()
+	input := "name,id\na," + id.String() + "\nb,invalid\n"
+	result, err := ReadCSVColumn(csv.NewReader(strings.NewReader(input)), 1, WithCSVHeader("id"))
+	if !errors.Is(err, ulid.ErrDataSize) || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("Was expecting data size error on line 3, got %v", err)
+	}
+	if len(result) != 1 || result[0] != id {
+		t.Fatalf("Read IDs %v did not match with %s", result, id.String())
+	}
+	if _, err = ReadCSVColumn(csv.NewReader(strings.NewReader(input)), 0, WithCSVHeader("missing")); err == nil {
+		t.Fatalf("Was expecting missing column error")
+	}
+	r := csv.NewReader(strings.NewReader("a," + id.String() + "\n"))
+	if result, err = ReadCSVColumn(r, 1); err != nil || len(result) != 1 || result[0] != id {
+		t.Fatalf("Read IDs %v did not match with %s: %v", result, id.String(), err)
+	}
+}
@@ -0,0 +1,100 @@
+package idx
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDialect is returned by ColumnDDL for dialects it has no recommendation for.
+var ErrDialect = errors.New("idx: unsupported dialect")
+
+// ColumnDefinition holds the DDL snippets recommended for an ID column.
+type ColumnDefinition struct {
+	// Type is the column type, e.g. BINARY(16).
+	Type string
+	// Column is the column definition for CREATE TABLE, e.g. id BINARY(16) NOT NULL.
+	Column string
+	// PrimaryKey is the table constraint making the column the primary key, e.g. PRIMARY KEY (id).
+	PrimaryKey string
+	// Index creates a secondary index on the column. It is empty unless a table name is set.
+	Index string
+}
+
+// DDLOption configures ColumnDDL.
+type DDLOption func(*ddlConfig)
+
+type ddlConfig struct {
+	table    string
+	column   string
+	format   ValueFormat
+	nullable bool
+}
+
+// WithDDLTable sets the table the index snippet is created on.
+func WithDDLTable(name string) DDLOption {
+	return func(c *ddlConfig) {
+		c.table = name
+	}
+}
+
+// WithDDLColumn sets the column name, which is id by default.
+func WithDDLColumn(name string) DDLOption {
+	return func(c *ddlConfig) {
+		c.column = name
+	}
+}
+
+// WithDDLFormat sets the stored representation, ValueBinary by default. ValueUUID selects the native uuid
+// and uniqueidentifier types of PostgreSQL and SQL Server; the latter only keeps IDs ordered when
+// they are stored through SQLServerID.
+func WithDDLFormat(format ValueFormat) DDLOption {
+	return func(c *ddlConfig) {
+		c.format = format
+	}
+}
+
+// WithDDLNullable leaves out the NOT NULL constraint.
+func WithDDLNullable() DDLOption {
+	return func(c *ddlConfig) {
+		c.nullable = true
+	}
+}
+
+// ColumnDDL returns the recommended definition of an ID column for dialect, which is named like the gorm
+// dialects: mysql (also used for MariaDB), postgres, sqlite or sqlserver.
+//
+//	def, err := idx.ColumnDDL("mysql", idx.WithDDLTable("orders"), idx.WithDDLColumn("customer_id"))
+//	// def.Column: customer_id BINARY(16) NOT NULL
+//	// def.Index:  CREATE INDEX idx_orders_customer_id ON orders (customer_id)
+//
+// The types match what Value, UUID and gormidx.DataTypeOf use, so schema files and gormidx.AutoMigrate agree.
+func ColumnDDL(dialect string, opts ...DDLOption) (ColumnDefinition, error) {
+	cfg := ddlConfig{column: "id"}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	columnType, ok := ddlTypes[dialect][cfg.format]
+	if !ok {
+		return ColumnDefinition{}, fmt.Errorf("%w %q", ErrDialect, dialect)
+	}
+	def := ColumnDefinition{
+		Type:       columnType,
+		Column:     cfg.column + " " + columnType,
+		PrimaryKey: "PRIMARY KEY (" + cfg.column + ")",
+	}
+	if !cfg.nullable {
+		def.Column += " NOT NULL"
+	}
+	if cfg.table != "" {
+		def.Index = fmt.Sprintf("CREATE INDEX idx_%s_%s ON %s (%s)", cfg.table, cfg.column, cfg.table, cfg.column)
+	}
+	return def, nil
+}
+
+// ddlTypes maps dialects and formats to column types.
+var ddlTypes = map[string]map[ValueFormat]string{
+	"mysql":     {ValueBinary: "BINARY(16)", ValueText: "CHAR(26)", ValueUUID: "CHAR(36)"},
+	"postgres":  {ValueBinary: "bytea", ValueText: "CHAR(26)", ValueUUID: "uuid"},
+	"sqlite":    {ValueBinary: "BLOB", ValueText: "CHAR(26)", ValueUUID: "CHAR(36)"},
+	"sqlserver": {ValueBinary: "BINARY(16)", ValueText: "CHAR(26)", ValueUUID: "uniqueidentifier"},
+}
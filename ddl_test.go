@@ -0,0 +1,41 @@
+package idx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestColumnDDL(t *testing.T) {
+	expected := map[string]string{
+		"mysql":     "BINARY(16)",
+		"postgres":  "bytea",
+		"sqlite":    "BLOB",
+		"sqlserver": "BINARY(16)",
+	}
+	for dialect, columnType := range expected {
+		def, err := ColumnDDL(dialect)
+		if err != nil {
+			t.Fatalf("Got error while generating DDL for %s %v", dialect, err)
+		}
+		if def.Type != columnType || def.Column != "id "+columnType+" NOT NULL" || def.PrimaryKey != "PRIMARY KEY (id)" || def.Index != "" {
+			t.Fatalf("Definition %+v for %s did not match with %s", def, dialect, columnType)
+		}
+	}
+
+	def, err := ColumnDDL("postgres", WithDDLTable("orders"), WithDDLColumn("customer_id"), WithDDLFormat(ValueUUID), WithDDLNullable())
+	if err != nil {
+		t.Fatalf("Got error while generating DDL %v", err)
+	}
+	if def.Column != "customer_id uuid" || def.Index != "CREATE INDEX idx_orders_customer_id ON orders (customer_id)" {
+		t.Fatalf("Unexpected definition %+v", def)
+	}
+	if def, _ = ColumnDDL("sqlserver", WithDDLFormat(ValueUUID)); def.Type != "uniqueidentifier" {
+		t.Fatalf("Unexpected definition %+v", def)
+	}
+	if def, _ = ColumnDDL("mysql", WithDDLFormat(ValueText)); def.Type != "CHAR(26)" {
+		t.Fatalf("Unexpected definition %+v", def)
+	}
+	if _, err = ColumnDDL("oracle"); !errors.Is(err, ErrDialect) {
+		t.Fatalf("Was expecting dialect error, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package idx
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	// ErrUnsorted is returned by EncodeSorted when the IDs are not in ascending order.
+	ErrUnsorted = errors.New("idx: ids are not sorted")
+	// ErrCorruptDelta is returned by DecodeSorted when the input is truncated or malformed.
+	ErrCorruptDelta = errors.New("idx: corrupt delta encoding")
+)
+
+// EncodeSorted returns a compact encoding of ids, which must be sorted in ascending order. It starts with
+// the number of IDs as a uvarint. Each ID is then stored as the uvarint difference between its timestamp
+// and the previous one, followed by the 10 entropy bytes when the timestamp changed, or by the uvarint
+// difference from the previous entropy when it did not. IDs generated by NewID within the same millisecond
+// have monotonic entropy, so bursts of IDs shrink to a few bytes each.
+func EncodeSorted(ids []ID) ([]byte, error) {
+	return AppendEncodeSorted(make([]byte, 0, binary.MaxVarintLen64+len(ids)*8), ids)
+}
+
+// AppendEncodeSorted appends the EncodeSorted form of ids to dst.
+func AppendEncodeSorted(dst []byte, ids []ID) ([]byte, error) {
+	dst = binary.AppendUvarint(dst, uint64(len(ids)))
+	var prev ID
+	for i, id := range ids {
+		if i > 0 && id.Compare(prev) < 0 {
+			return nil, ErrUnsorted
+		}
+		ts, prevTs := timestamp(id), timestamp(prev)
+		dst = binary.AppendUvarint(dst, ts-prevTs)
+		if i == 0 || ts != prevTs {
+			dst = append(dst, id[6:]...)
+		} else {
+			hi, lo := entropy(id)
+			prevHi, prevLo := entropy(prev)
+			dHi, dLo := sub128(hi, lo, prevHi, prevLo)
+			dst = appendUvarint128(dst, dHi, dLo)
+		}
+		prev = id
+	}
+	return dst, nil
+}
+
+// DecodeSorted decodes the output of EncodeSorted.
+func DecodeSorted(b []byte) ([]ID, error) {
+	count, n := binary.Uvarint(b)
+	if n <= 0 {
+		return nil, ErrCorruptDelta
+	}
+	b = b[n:]
+	// Every ID takes at least two bytes, which bounds the preallocation for corrupt counts.
+	if count > uint64(len(b)/2) {
+		return nil, ErrCorruptDelta
+	}
+	ids := make([]ID, 0, count)
+	var prev ID
+	for i := uint64(0); i < count; i++ {
+		delta, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, ErrCorruptDelta
+		}
+		b = b[n:]
+		ts := timestamp(prev) + delta
+		if ts < delta || ts > maxTimestamp {
+			return nil, ErrCorruptDelta
+		}
+		var id ID
+		putTimestamp(&id, ts)
+		if i == 0 || delta != 0 {
+			if len(b) < 10 {
+				return nil, ErrCorruptDelta
+			}
+			copy(id[6:], b[:10])
+			b = b[10:]
+		} else {
+			dHi, dLo, n := uvarint128(b)
+			if n <= 0 {
+				return nil, ErrCorruptDelta
+			}
+			b = b[n:]
+			hi, lo := entropy(prev)
+			lo, carry := lo+dLo, uint64(0)
+			if lo < dLo {
+				carry = 1
+			}
+			hi += dHi + carry
+			if hi > 0xFFFF {
+				return nil, ErrCorruptDelta
+			}
+			binary.BigEndian.PutUint16(id[6:], uint16(hi))
+			binary.BigEndian.PutUint64(id[8:], lo)
+		}
+		ids = append(ids, id)
+		prev = id
+	}
+	if len(b) != 0 {
+		return nil, ErrCorruptDelta
+	}
+	return ids, nil
+}
+
+const maxTimestamp = 1<<48 - 1
+
+func timestamp(id ID) uint64 {
+	return uint64(id[5]) | uint64(id[4])<<8 | uint64(id[3])<<16 | uint64(id[2])<<24 | uint64(id[1])<<32 | uint64(id[0])<<40
+}
+
+func putTimestamp(id *ID, ts uint64) {
+	id[0], id[1], id[2], id[3], id[4], id[5] = byte(ts>>40), byte(ts>>32), byte(ts>>24), byte(ts>>16), byte(ts>>8), byte(ts)
+}
+
+// entropy returns the 80 entropy bits of id as their upper 16 and lower 64 bits.
+func entropy(id ID) (uint64, uint64) {
+	return uint64(binary.BigEndian.Uint16(id[6:])), binary.BigEndian.Uint64(id[8:])
+}
+
+func sub128(hi, lo, yHi, yLo uint64) (uint64, uint64) {
+	borrow := uint64(0)
+	if lo < yLo {
+		borrow = 1
+	}
+	return hi - yHi - borrow, lo - yLo
+}
+
+// appendUvarint128 appends the 128-bit value hi:lo in the uvarint format.
+func appendUvarint128(dst []byte, hi, lo uint64) []byte {
+	for hi != 0 || lo >= 0x80 {
+		dst = append(dst, byte(lo)|0x80)
+		lo = lo>>7 | hi<<57
+		hi >>= 7
+	}
+	return append(dst, byte(lo))
+}
+
+// uvarint128 decodes a value written by appendUvarint128, returning the number of bytes read or 0 when b
+// is truncated or the value does not fit in 128 bits.
+func uvarint128(b []byte) (uint64, uint64, int) {
+	var hi, lo uint64
+	for i, c := range b {
+		if i == 19 {
+			return 0, 0, 0
+		}
+		v := uint64(c & 0x7F)
+		shift := uint(7 * i)
+		if shift < 64 {
+			lo |= v << shift
+			if shift > 57 {
+				hi |= v >> (64 - shift)
+			}
+		} else {
+			hi |= v << (shift - 64)
+		}
+		if c < 0x80 {
+			return hi, lo, i + 1
+		}
+	}
+	return 0, 0, 0
+}
@@ -0,0 +1,69 @@
+package idx
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestEncodeSorted(t *testing.T) {
+	ids := make([]ID, 10000)
+	for i := range ids {
+		ids[i] = NewID()
+	}
+	ids = append(ids, ID{0, 0, 0, 0, 0, 1, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, NilID)
+	ids = append(ids, ID{0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, ID{0, 0, 0, 0, 0, 1, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF})
+	slices.SortFunc(ids, ID.Compare)
+	b, err := EncodeSorted(ids)
+	if err != nil {
+		t.Fatalf("Got error while encoding IDs %v", err)
+	}
+	if len(b) >= len(ids)*16/2 {
+		t.Fatalf("Encoding of %d bytes is not compact for %d IDs", len(b), len(ids))
+	}
+	result, err := DecodeSorted(b)
+	if err != nil {
+		t.Fatalf("Got error while decoding IDs %v", err)
+	}
+	if !slices.Equal(result, ids) {
+		t.Fatalf("Decoded IDs did not match with original IDs")
+	}
+
+	if b, err = EncodeSorted(nil); err != nil || len(b) != 1 {
+		t.Fatalf("Unexpected encoding %v of empty list: %v", b, err)
+	}
+	if result, err = DecodeSorted(b); err != nil || len(result) != 0 {
+		t.Fatalf("Was expecting empty list, got %v: %v", result, err)
+	}
+	if _, err = EncodeSorted([]ID{NewID(), NilID}); !errors.Is(err, ErrUnsorted) {
+		t.Fatalf("Was expecting unsorted error, got %v", err)
+	}
+}
+
+func TestDecodeSorted(t *testing.T) {
+	b, _ := EncodeSorted([]ID{NewID(), NewID()})
+	invalid := [][]byte{
+		nil,
+		{0x80},
+		{2, 0},
+		b[:len(b)-1],
+		append(slices.Clip(b), 0),
+		{1, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x01, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+	}
+	for _, val := range invalid {
+		if _, err := DecodeSorted(val); !errors.Is(err, ErrCorruptDelta) {
+			t.Fatalf("Was expecting corrupt delta error for %v, got %v", val, err)
+		}
+	}
+}
+
+func TestUvarint128(t *testing.T) {
+	values := [][2]uint64{{0, 0}, {0, 127}, {0, 128}, {0, 1<<64 - 1}, {1, 0}, {0xFFFF, 1<<64 - 1}, {1<<64 - 1, 1<<64 - 1}}
+	for _, v := range values {
+		b := appendUvarint128(nil, v[0], v[1])
+		hi, lo, n := uvarint128(b)
+		if n != len(b) || hi != v[0] || lo != v[1] {
+			t.Fatalf("Decoded value %x:%x did not match with %x:%x", hi, lo, v[0], v[1])
+		}
+	}
+}
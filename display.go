@@ -0,0 +1,55 @@
+package idx
+
+import (
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// DisplayEncodedSize is the length of the display form of an ID.
+	DisplayEncodedSize = ulid.EncodedSize + (ulid.EncodedSize-1)/displayGroupSize
+	displayGroupSize   = 6
+)
+
+// Display returns the ULID string split into dash-separated groups of six characters, such as
+// "01HAK8-JPF7S0-SFMJ2X-96W37W-XB", for invoices and support tickets.
+func (id ID) Display() string {
+	var buf [ulid.EncodedSize]byte
+	_ = ulid.ULID(id).MarshalTextTo(buf[:])
+	var dst [DisplayEncodedSize]byte
+	n := 0
+	for i, c := range buf {
+		if i > 0 && i%displayGroupSize == 0 {
+			dst[n] = '-'
+			n++
+		}
+		dst[n] = c
+		n++
+	}
+	return string(dst[:])
+}
+
+// FromDisplay parses a string produced by Display. Dashes and spaces are ignored wherever they appear, so
+// values copied with different grouping are accepted as long as 26 ULID characters remain.
+func FromDisplay(val string) (ID, error) {
+	var buf [ulid.EncodedSize]byte
+	n := 0
+	for i := 0; i < len(val); i++ {
+		c := val[i]
+		if c == '-' || c == ' ' {
+			continue
+		}
+		if n == len(buf) {
+			return NilID, ulid.ErrDataSize
+		}
+		buf[n] = c
+		n++
+	}
+	if n != len(buf) {
+		return NilID, ulid.ErrDataSize
+	}
+	var id ID
+	if err := decodeText(buf[:], &id); err != nil {
+		return NilID, err
+	}
+	return id, nil
+}
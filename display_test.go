@@ -0,0 +1,57 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestID_Display(t *testing.T) {
+	id, err := FromString("01HAK8JPF7S0SFMJ2X96W37WXB")
+	if err != nil {
+		t.Fatalf("Got error while parsing ID %v", err)
+	}
+	if id.Display() != "01HAK8-JPF7S0-SFMJ2X-96W37W-XB" {
+		t.Fatalf("Unexpected display value %s", id.Display())
+	}
+	for i := 0; i < 1000; i++ {
+		id = NewID()
+		display := id.Display()
+		if len(display) != DisplayEncodedSize {
+			t.Fatalf("Display value %s of %s is not %d characters long", display, id.String(), DisplayEncodedSize)
+		}
+		decoded, err := FromDisplay(display)
+		if err != nil {
+			t.Fatalf("Got error while decoding %s: %v", display, err)
+		}
+		if decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+		}
+	}
+}
+
+func TestFromDisplay(t *testing.T) {
+	id := NewID()
+	for _, val := range []string{id.String(), strings.ReplaceAll(id.Display(), "-", " "), strings.ToLower(id.Display())} {
+		decoded, err := FromDisplay(val)
+		if err != nil {
+			t.Fatalf("Got error while decoding %s: %v", val, err)
+		}
+		if decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+		}
+	}
+	invalid := map[string]error{
+		"":                                ulid.ErrDataSize,
+		"01HAK8-JPF7S0-SFMJ2X-96W37W-X":   ulid.ErrDataSize,
+		"01HAK8-JPF7S0-SFMJ2X-96W37W-XBC": ulid.ErrDataSize,
+		"01HAK8-JPF7S0-SFMJ2X-96W37W-XU":  ulid.ErrInvalidCharacters,
+		"81HAK8-JPF7S0-SFMJ2X-96W37W-XB":  ulid.ErrOverflow,
+	}
+	for val, expected := range invalid {
+		if _, err := FromDisplay(val); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+}
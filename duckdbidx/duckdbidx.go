@@ -0,0 +1,51 @@
+// Package duckdbidx appends idx IDs to DuckDB tables through the Appender of go-duckdb
+// (https://pkg.go.dev/github.com/marcboeker/go-duckdb). The appender writes values directly into
+// column vectors without calling driver.Valuer, so IDs have to be converted to the types it accepts.
+package duckdbidx
+
+import (
+	"database/sql/driver"
+	"github.com/ieshan/idx"
+	"github.com/marcboeker/go-duckdb"
+)
+
+// Appender is a duckdb.Appender whose AppendRow accepts idx.ID and *idx.ID values for UUID and BLOB
+// columns, so exports do not convert IDs to strings row by row:
+//
+//	a, err := duckdb.NewAppenderFromConn(conn, "", "orders")
+//	appender := duckdbidx.Appender{Appender: a}
+//	err = appender.AppendRow(order.ID, order.Amount)
+//
+// UUID columns sort like the IDs. A nil *idx.ID is appended as NULL, while idx.NilID is appended as the
+// zero UUID.
+type Appender struct {
+	*duckdb.Appender
+}
+
+// AppendRow converts the ID arguments and appends the row. args is left unchanged.
+func (a Appender) AppendRow(args ...driver.Value) error {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case idx.ID:
+			values[i] = v[:]
+		case *idx.ID:
+			if v != nil {
+				values[i] = v[:]
+			}
+		default:
+			values[i] = arg
+		}
+	}
+	return a.Appender.AppendRow(values...)
+}
+
+// UUID returns id as a DuckDB UUID value.
+func UUID(id idx.ID) duckdb.UUID {
+	return duckdb.UUID(id)
+}
+
+// FromUUID returns the ID held by a DuckDB UUID value, as scanned from UUID columns.
+func FromUUID(u duckdb.UUID) idx.ID {
+	return idx.ID(u)
+}
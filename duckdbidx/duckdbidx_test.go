@@ -0,0 +1,74 @@
+package duckdbidx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"github.com/ieshan/idx"
+	"github.com/marcboeker/go-duckdb"
+	"testing"
+)
+
+func TestAppender(t *testing.T) {
+	connector, err := duckdb.NewConnector("", nil)
+	if err != nil {
+		t.Fatalf("Got error while creating connector %v", err)
+	}
+	defer connector.Close()
+	conn, err := connector.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("Got error while connecting %v", err)
+	}
+	defer conn.Close()
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	if _, err = db.Exec("CREATE TABLE orders (id UUID, raw BLOB, parent_id UUID, amount INTEGER)"); err != nil {
+		t.Fatalf("Got error while creating table %v", err)
+	}
+
+	a, err := duckdb.NewAppenderFromConn(conn.(driver.Conn), "", "orders")
+	if err != nil {
+		t.Fatalf("Got error while creating appender %v", err)
+	}
+	appender := Appender{Appender: a}
+	ids := []idx.ID{idx.NewID(), idx.NewID(), idx.NewID()}
+	for i := len(ids) - 1; i >= 0; i-- {
+		var parent *idx.ID
+		if i > 0 {
+			parent = &ids[i-1]
+		}
+		if err = appender.AppendRow(ids[i], ids[i], parent, int32(i)); err != nil {
+			t.Fatalf("Got error while appending row %v", err)
+		}
+	}
+	if err = appender.Close(); err != nil {
+		t.Fatalf("Got error while flushing appender %v", err)
+	}
+
+	rows, err := db.Query("SELECT id, raw, parent_id FROM orders ORDER BY id")
+	if err != nil {
+		t.Fatalf("Got error while querying %v", err)
+	}
+	defer rows.Close()
+	i := 0
+	for ; rows.Next(); i++ {
+		var u duckdb.UUID
+		var raw idx.ID
+		var parent *duckdb.UUID
+		if err = rows.Scan(&u, &raw, &parent); err != nil {
+			t.Fatalf("Got error while scanning %v", err)
+		}
+		if FromUUID(u) != ids[i] || raw != ids[i] {
+			t.Fatalf("Original ID (%s) did not match with %s and %s", ids[i].String(), FromUUID(u).String(), raw.String())
+		}
+		if (i == 0) != (parent == nil) || (parent != nil && FromUUID(*parent) != ids[i-1]) {
+			t.Fatalf("Unexpected parent ID %v for row %d", parent, i)
+		}
+	}
+	if i != len(ids) {
+		t.Fatalf("Was expecting %d rows, got %d", len(ids), i)
+	}
+	if UUID(ids[0]) != duckdb.UUID(ids[0]) {
+		t.Fatalf("UUID did not match with the ID bytes")
+	}
+}
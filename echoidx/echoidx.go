@@ -0,0 +1,22 @@
+// Package echoidx binds echo (https://pkg.go.dev/github.com/labstack/echo/v4) path parameters to idx.ID
+// values.
+package echoidx
+
+import (
+	"errors"
+	"github.com/ieshan/idx"
+	"github.com/labstack/echo/v4"
+)
+
+// Param parses the path parameter name of c. When it is missing or invalid, Param returns an
+// *echo.HTTPError with status 400 and the message of the *idx.ParamError, which is kept as the internal
+// error, so the handler can return it as is.
+func Param(c echo.Context, name string) (idx.ID, error) {
+	id, err := idx.ParseParam(name, c.Param(name))
+	if err != nil {
+		var paramErr *idx.ParamError
+		errors.As(err, &paramErr)
+		return idx.NilID, echo.NewHTTPError(paramErr.StatusCode(), err.Error()).SetInternal(err)
+	}
+	return id, nil
+}
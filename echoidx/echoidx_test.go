@@ -0,0 +1,36 @@
+package echoidx
+
+import (
+	"errors"
+	"github.com/ieshan/idx"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParam(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	c.SetParamNames("id")
+
+	id := idx.NewID()
+	c.SetParamValues(id.String())
+	if parsed, err := Param(c, "id"); err != nil || parsed != id {
+		t.Fatalf("Parsed ID (%s) did not match with %s: %v", parsed.String(), id.String(), err)
+	}
+
+	c.SetParamValues("invalid")
+	_, err := Param(c, "id")
+	var httpErr *echo.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+		t.Fatalf("Was expecting status 400 error, got %v", err)
+	}
+	var paramErr *idx.ParamError
+	if httpErr.Message != `idx: path parameter id: "invalid" is not a valid id` || !errors.As(httpErr.Internal, &paramErr) {
+		t.Fatalf("Unexpected error %v", httpErr)
+	}
+	if _, err = Param(c, "missing"); !errors.As(err, &httpErr) || !errors.Is(httpErr.Internal, idx.ErrParamMissing) {
+		t.Fatalf("Was expecting missing parameter error, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package idx
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Actions of the Elasticsearch and OpenSearch bulk API, see AppendElasticBulkMeta.
+const (
+	ElasticBulkIndex  = "index"
+	ElasticBulkCreate = "create"
+	ElasticBulkUpdate = "update"
+	ElasticBulkDelete = "delete"
+)
+
+// ElasticDocumentID returns the _id of the document of id in Elasticsearch and OpenSearch, which is its
+// ULID string. Document IDs sort like the IDs, which keeps _id range queries and sorts meaningful.
+func ElasticDocumentID(id ID) string {
+	return id.String()
+}
+
+// ElasticRouting returns a routing value derived from the 80 random bits of id, so documents spread
+// evenly over the shards instead of following the time component of the IDs. Requests on a single
+// document have to pass the same value.
+func ElasticRouting(id ID) string {
+	return hex.EncodeToString(id[6:])
+}
+
+// AppendElasticBulkMeta appends the action line of a bulk request for the document of id to dst, with
+// the _id set by ElasticDocumentID and the routing set by ElasticRouting:
+//
+//	body = idx.AppendElasticBulkMeta(body, idx.ElasticBulkIndex, "orders", order.ID)
+//	body = append(body, source...)
+//	body = append(body, '\n')
+//
+// The line ends with a newline. index is omitted when empty, for requests sent to /<index>/_bulk.
+func AppendElasticBulkMeta(dst []byte, action, index string, id ID) []byte {
+	dst = append(dst, `{"`...)
+	dst = append(dst, action...)
+	dst = append(dst, `":{`...)
+	if index != "" {
+		name, _ := json.Marshal(index)
+		dst = append(dst, `"_index":`...)
+		dst = append(dst, name...)
+		dst = append(dst, ',')
+	}
+	dst = append(dst, `"_id":"`...)
+	dst = id.AppendString(dst)
+	dst = append(dst, `","routing":"`...)
+	dst = hex.AppendEncode(dst, id[6:])
+	return append(dst, "\"}}\n"...)
+}
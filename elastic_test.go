@@ -0,0 +1,44 @@
+package idx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestElasticRouting(t *testing.T) {
+	id := NewID()
+	if ElasticDocumentID(id) != id.String() {
+		t.Fatalf("Document ID %s did not match with %s", ElasticDocumentID(id), id.String())
+	}
+	routing := ElasticRouting(id)
+	if len(routing) != 20 || routing != ElasticRouting(id) {
+		t.Fatalf("Unexpected routing %q", routing)
+	}
+	// IDs generated in the same millisecond share their time component but not their routing.
+	if other := MinIDAt(id.Time()); ElasticRouting(other) == routing {
+		t.Fatalf("Routing should not depend on the timestamp")
+	}
+}
+
+func TestAppendElasticBulkMeta(t *testing.T) {
+	id := NewID()
+	line := AppendElasticBulkMeta(nil, ElasticBulkIndex, `orders"2024`, id)
+	if line[len(line)-1] != '\n' {
+		t.Fatalf("Action line %q does not end with a newline", line)
+	}
+	var meta map[string]map[string]string
+	if err := json.Unmarshal(line, &meta); err != nil {
+		t.Fatalf("Got error while decoding action line %v", err)
+	}
+	expected := map[string]string{"_index": `orders"2024`, "_id": id.String(), "routing": ElasticRouting(id)}
+	for k, v := range expected {
+		if meta[ElasticBulkIndex][k] != v {
+			t.Fatalf("Action line %q has %s %q, was expecting %q", line, k, meta[ElasticBulkIndex][k], v)
+		}
+	}
+
+	line = AppendElasticBulkMeta([]byte("prev\n"), ElasticBulkDelete, "", id)
+	if string(line) != "prev\n"+`{"delete":{"_id":"`+id.String()+`","routing":"`+ElasticRouting(id)+`"}}`+"\n" {
+		t.Fatalf("Unexpected action line %q", line)
+	}
+}
@@ -0,0 +1,96 @@
+package idx
+
+import (
+	"encoding/binary"
+	"github.com/oklog/ulid/v2"
+	"strconv"
+	"strings"
+)
+
+// Encoding is a 32-character alphabet used to render IDs as 26-character strings with the same bit layout
+// as ULIDs, in the spirit of base32.Encoding. Alphabets in ascending byte order keep strings sortable.
+type Encoding struct {
+	encode    [32]byte
+	decodeMap [256]byte
+}
+
+// CrockfordEncoding is the default ULID alphabet. It renders upper-case strings and decodes either case.
+var CrockfordEncoding = func() *Encoding {
+	e := NewEncoding(ulid.Encoding)
+	e.decodeMap = dec
+	return e
+}()
+
+// LowercaseEncoding renders the ULID alphabet in lower case, for systems that lower-case identifiers.
+// Like CrockfordEncoding, it decodes either case.
+var LowercaseEncoding = func() *Encoding {
+	e := NewEncoding(strings.ToLower(ulid.Encoding))
+	e.decodeMap = dec
+	return e
+}()
+
+// NewEncoding returns an Encoding for the given alphabet, which must consist of 32 distinct ASCII characters.
+// Decoding is case-sensitive. Like base32.NewEncoding, it panics on an invalid alphabet.
+func NewEncoding(alphabet string) *Encoding {
+	if len(alphabet) != 32 {
+		panic("idx: encoding alphabet is not 32 bytes long")
+	}
+	e := &Encoding{}
+	copy(e.encode[:], alphabet)
+	for i := range e.decodeMap {
+		e.decodeMap[i] = 0xFF
+	}
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 0x80 {
+			panic("idx: encoding alphabet contains non-ASCII character " + strconv.QuoteRune(rune(c)))
+		}
+		if e.decodeMap[c] != 0xFF {
+			panic("idx: encoding alphabet contains duplicate character " + strconv.QuoteRune(rune(c)))
+		}
+		e.decodeMap[c] = byte(i)
+	}
+	return e
+}
+
+// AppendEncode appends the 26-character encoding of id to dst and returns the extended buffer.
+func (e *Encoding) AppendEncode(dst []byte, id ID) []byte {
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+	var buf [ulid.EncodedSize]byte
+	for i := ulid.EncodedSize - 1; i >= 0; i-- {
+		buf[i] = e.encode[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return append(dst, buf[:]...)
+}
+
+// EncodeToString returns the 26-character encoding of id.
+func (e *Encoding) EncodeToString(id ID) string {
+	var buf [ulid.EncodedSize]byte
+	return string(e.AppendEncode(buf[:0], id))
+}
+
+// DecodeString parses a 26-character string produced by EncodeToString.
+func (e *Encoding) DecodeString(val string) (ID, error) {
+	if len(val) != ulid.EncodedSize {
+		return NilID, ulid.ErrDataSize
+	}
+	var hi, lo uint64
+	for i := 0; i < len(val); i++ {
+		d := e.decodeMap[val[i]]
+		if d == 0xFF {
+			return NilID, ulid.ErrInvalidCharacters
+		}
+		// The first character only carries 3 bits, as 26 characters encode 130 bits
+		if i == 0 && d > 7 {
+			return NilID, ulid.ErrOverflow
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(d)
+	}
+	var id ID
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
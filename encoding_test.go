@@ -0,0 +1,96 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestCrockfordEncoding(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		encoded := CrockfordEncoding.EncodeToString(id)
+		if encoded != id.String() {
+			t.Fatalf("Encoded value %s did not match with ID %s", encoded, id.String())
+		}
+		for _, val := range []string{encoded, strings.ToLower(encoded)} {
+			decoded, err := CrockfordEncoding.DecodeString(val)
+			if err != nil {
+				t.Fatalf("Got error while decoding %s: %v", val, err)
+			}
+			if decoded != id {
+				t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+			}
+		}
+	}
+}
+
+func TestLowercaseEncoding(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		encoded := LowercaseEncoding.EncodeToString(id)
+		if encoded != strings.ToLower(id.String()) {
+			t.Fatalf("Encoded value %s is not the lower-case form of %s", encoded, id.String())
+		}
+		for _, val := range []string{encoded, id.String()} {
+			decoded, err := LowercaseEncoding.DecodeString(val)
+			if err != nil {
+				t.Fatalf("Got error while decoding %s: %v", val, err)
+			}
+			if decoded != id {
+				t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+			}
+		}
+		if LowercaseEncoding.EncodeToString(id) != encoded {
+			t.Fatalf("Round trip of %s is not stable", encoded)
+		}
+	}
+}
+
+func TestNewEncoding(t *testing.T) {
+	// An alphabet without vowels, in ascending byte order
+	enc := NewEncoding("0123456789BCDFGHJKLMNPQRSTVWXYZb")
+	prev := ""
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		encoded := enc.EncodeToString(id)
+		if strings.ContainsAny(encoded, "AEIOU") {
+			t.Fatalf("Encoded value %s contains characters outside the alphabet", encoded)
+		}
+		if encoded <= prev {
+			t.Fatalf("Encoded value %s does not sort after %s", encoded, prev)
+		}
+		prev = encoded
+		decoded, err := enc.DecodeString(encoded)
+		if err != nil {
+			t.Fatalf("Got error while decoding %s: %v", encoded, err)
+		}
+		if decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+		}
+	}
+
+	invalid := map[string]error{
+		"":                           ulid.ErrDataSize,
+		"01HAK8JPF7S0SFMJ2X96W37WXA": ulid.ErrInvalidCharacters,
+		"01hbk8jpf7s0sfmj2x96w37wxb": ulid.ErrInvalidCharacters,
+		"81HBK8JPF7S0SFMJ2X96W37WXB": ulid.ErrOverflow,
+	}
+	for val, expected := range invalid {
+		if _, err := enc.DecodeString(val); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+
+	for _, alphabet := range []string{"short", "0023456789BCDFGHJKLMNPQRSTVWXYZb", "0123456789BCDFGHJKLMNPQRSTVWXYZ\xff"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Was expecting panic for alphabet %q", alphabet)
+				}
+			}()
+			NewEncoding(alphabet)
+		}()
+	}
+}
@@ -0,0 +1,72 @@
+// Package entidx declares ent (https://entgo.io) schema fields holding idx.ID values.
+//
+//	func (User) Fields() []ent.Field {
+//		return []ent.Field{
+//			entidx.ID(),
+//			entidx.Field("team_id"),
+//			entidx.OptionalField("parent_id"),
+//		}
+//	}
+//
+// The builders are returned as ent.Field, so fields needing other options are declared with SchemaType
+// directly:
+//
+//	field.Other("owner_id", idx.ID{}).SchemaType(entidx.SchemaType).Immutable()
+package entidx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/field"
+	"github.com/ieshan/idx"
+)
+
+// SchemaType maps each ent dialect to the column type holding the 16 bytes written by idx.ID.Value.
+var SchemaType = map[string]string{
+	dialect.MySQL:    "binary(16)",
+	dialect.Postgres: "bytea",
+	dialect.SQLite:   "blob",
+}
+
+// TextSchemaType maps each ent dialect to the column type of fields declared with TextField.
+var TextSchemaType = map[string]string{
+	dialect.MySQL:    "char(26)",
+	dialect.Postgres: "char(26)",
+	dialect.SQLite:   "text",
+}
+
+// TextValueScanner stores IDs as ULID strings, for fields declared with TextField. NULL is scanned as
+// idx.NilID.
+var TextValueScanner = field.ValueScannerFunc[idx.ID, *sql.NullString]{
+	V: func(id idx.ID) (driver.Value, error) {
+		return id.String(), nil
+	},
+	S: func(s *sql.NullString) (idx.ID, error) {
+		if !s.Valid {
+			return idx.NilID, nil
+		}
+		return idx.FromString(s.String)
+	},
+}
+
+// ID returns the primary key field of an ent schema, generated with idx.NewID when it is not set.
+func ID() ent.Field {
+	return field.Other("id", idx.ID{}).SchemaType(SchemaType).Default(idx.NewID).Unique().Immutable()
+}
+
+// Field returns a required idx.ID field stored as 16 bytes.
+func Field(name string) ent.Field {
+	return field.Other(name, idx.ID{}).SchemaType(SchemaType)
+}
+
+// OptionalField returns an idx.ID field stored as 16 bytes in a nullable column, and generated as *idx.ID.
+func OptionalField(name string) ent.Field {
+	return field.Other(name, idx.ID{}).SchemaType(SchemaType).Optional().Nillable()
+}
+
+// TextField returns a required idx.ID field stored as a 26-character ULID string.
+func TextField(name string) ent.Field {
+	return field.String(name).GoType(idx.ID{}).ValueScanner(TextValueScanner).SchemaType(TextSchemaType)
+}
@@ -0,0 +1,60 @@
+package entidx
+
+import (
+	"database/sql"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/schema/field"
+	"github.com/ieshan/idx"
+	"testing"
+)
+
+func TestID(t *testing.T) {
+	desc := ID().Descriptor()
+	if desc.Err != nil {
+		t.Fatalf("Got error in field descriptor %v", desc.Err)
+	}
+	if desc.Name != "id" || !desc.Unique || !desc.Immutable || desc.SchemaType[dialect.MySQL] != "binary(16)" {
+		t.Fatalf("Unexpected descriptor %+v", desc)
+	}
+	newID, ok := desc.Default.(func() idx.ID)
+	if !ok || newID() == idx.NilID {
+		t.Fatalf("Default is not an ID generator: %T", desc.Default)
+	}
+}
+
+func TestField(t *testing.T) {
+	for _, f := range []interface{ Descriptor() *field.Descriptor }{Field("team_id"), OptionalField("parent_id"), TextField("legacy_id")} {
+		desc := f.Descriptor()
+		if desc.Err != nil {
+			t.Fatalf("Got error in field descriptor %s %v", desc.Name, desc.Err)
+		}
+		if desc.Info.RType == nil || desc.Info.RType.Ident != "idx.ID" {
+			t.Fatalf("Field %s does not have the idx.ID type: %+v", desc.Name, desc.Info)
+		}
+	}
+	if desc := OptionalField("parent_id").Descriptor(); !desc.Optional || !desc.Nillable {
+		t.Fatalf("Field is not optional and nillable")
+	}
+	if desc := TextField("legacy_id").Descriptor(); desc.SchemaType[dialect.Postgres] != "char(26)" || desc.ValueScanner == nil {
+		t.Fatalf("Unexpected descriptor %+v", desc)
+	}
+}
+
+func TestTextValueScanner(t *testing.T) {
+	id := idx.NewID()
+	value, err := TextValueScanner.Value(id)
+	if err != nil || value != id.String() {
+		t.Fatalf("Value %v did not match with %s: %v", value, id.String(), err)
+	}
+	s := TextValueScanner.ScanValue()
+	if err = s.Scan(value); err != nil {
+		t.Fatalf("Got error while scanning %v", err)
+	}
+	result, err := TextValueScanner.FromValue(s)
+	if err != nil || result != id {
+		t.Fatalf("Original ID (%s) did not match with scanned ID (%s): %v", id.String(), result.String(), err)
+	}
+	if result, err = TextValueScanner.FromValue(&sql.NullString{}); err != nil || result != idx.NilID {
+		t.Fatalf("Was expecting NilID for NULL, got %s: %v", result.String(), err)
+	}
+}
@@ -0,0 +1,53 @@
+package idx
+
+import (
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"time"
+)
+
+// EtcdKey returns the key path "<prefix>/<ulid>[/<elem>...]", e.g. /services/01HAK8JPF7S0SFMJ2X96W37WXB/config.
+// The ULID string holds no '/' and sorts like the IDs, so the keys of an ID stay together and listing
+// the prefix returns them in creation order. A trailing '/' of prefix is ignored.
+func EtcdKey(prefix string, id ID, elems ...string) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(prefix, "/"))
+	b.WriteByte('/')
+	b.WriteString(id.String())
+	for _, elem := range elems {
+		b.WriteByte('/')
+		b.WriteString(elem)
+	}
+	return b.String()
+}
+
+// ParseEtcdKey returns the ID of a key built by EtcdKey with the same prefix, e.g. from watch events:
+//
+//	for resp := range cli.Watch(ctx, "/services/", clientv3.WithPrefix()) {
+//		for _, ev := range resp.Events {
+//			id, err := idx.ParseEtcdKey("/services", string(ev.Kv.Key))
+//		}
+//	}
+//
+// ErrPrefix is returned when the key is not under prefix.
+func ParseEtcdKey(prefix, key string) (ID, error) {
+	rest, ok := strings.CutPrefix(key, strings.TrimSuffix(prefix, "/")+"/")
+	if !ok {
+		return NilID, ErrPrefix
+	}
+	if len(rest) > ulid.EncodedSize && rest[ulid.EncodedSize] == '/' {
+		rest = rest[:ulid.EncodedSize]
+	}
+	return FromString(rest)
+}
+
+// EtcdRange returns the [key, end) range holding the keys built by EtcdKey with prefix, including their
+// sub-paths, for IDs generated in [from, to):
+//
+//	key, end := idx.EtcdRange("/services", from, to)
+//	resp, err := cli.Get(ctx, key, clientv3.WithRange(end))
+//
+// Bounds have millisecond precision.
+func EtcdRange(prefix string, from, to time.Time) (string, string) {
+	return EtcdKey(prefix, MinIDAt(from)), EtcdKey(prefix, MinIDAt(to))
+}
@@ -0,0 +1,56 @@
+package idx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEtcdKey(t *testing.T) {
+	id := NewID()
+	key := EtcdKey("/services/", id, "config", "v1")
+	if key != "/services/"+id.String()+"/config/v1" {
+		t.Fatalf("Unexpected key %q", key)
+	}
+	for _, k := range []string{key, EtcdKey("/services", id)} {
+		parsed, err := ParseEtcdKey("/services", k)
+		if err != nil || parsed != id {
+			t.Fatalf("Original ID (%s) did not match with the ID from key %s: %v", id.String(), parsed.String(), err)
+		}
+	}
+	if _, err := ParseEtcdKey("/nodes", key); !errors.Is(err, ErrPrefix) {
+		t.Fatalf("Was expecting prefix error, got %v", err)
+	}
+	if _, err := ParseEtcdKey("/services", "/services/config"); err == nil {
+		t.Fatalf("Was expecting error for key without ID")
+	}
+}
+
+func TestEtcdRange(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	key, end := EtcdRange("/services", from, to)
+	inRange := func(k string) bool {
+		return k >= key && k < end
+	}
+	inside := []string{
+		EtcdKey("/services", MinIDAt(from)),
+		EtcdKey("/services", MinIDAt(from), "config"),
+		EtcdKey("/services", MaxIDAt(to.Add(-time.Millisecond)), "config"),
+	}
+	for _, k := range inside {
+		if !inRange(k) {
+			t.Fatalf("Key %q fell outside [%q, %q)", k, key, end)
+		}
+	}
+	outside := []string{
+		EtcdKey("/services", MinIDAt(to)),
+		EtcdKey("/services", MinIDAt(to), "config"),
+		EtcdKey("/services", MaxIDAt(from.Add(-time.Millisecond)), "config"),
+	}
+	for _, k := range outside {
+		if inRange(k) {
+			t.Fatalf("Key %q fell inside [%q, %q)", k, key, end)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package idx
+
+import (
+	"encoding/base64"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MarshalExtJSON returns the IDX as MongoDB Extended JSON in the canonical binary form,
+// {"$binary":{"base64":"...","subType":"04"}}, which mongoimport and aggregation pipelines accept.
+func (id ID) MarshalExtJSON() ([]byte, error) {
+	b := make([]byte, 0, 64)
+	b = append(b, `{"$binary":{"base64":"`...)
+	b = base64.StdEncoding.AppendEncode(b, id[:])
+	b = append(b, `","subType":"04"}}`...)
+	return b, nil
+}
+
+// MarshalExtJSONUUID returns the IDX as MongoDB Extended JSON in the {"$uuid":"..."} shorthand form.
+func (id ID) MarshalExtJSONUUID() ([]byte, error) {
+	b := make([]byte, 0, 48)
+	b = append(b, `{"$uuid":"`...)
+	b = appendUUID(b, id)
+	b = append(b, `"}`...)
+	return b, nil
+}
+
+// UnmarshalExtJSON populates the IDX from a MongoDB Extended JSON value. The canonical and legacy $binary
+// forms, the $uuid form and plain ULID strings are accepted.
+func (id *ID) UnmarshalExtJSON(b []byte) error {
+	doc := make([]byte, 0, len(b)+6)
+	doc = append(doc, `{"v":`...)
+	doc = append(doc, b...)
+	doc = append(doc, '}')
+	var wrapper struct {
+		V ID `bson:"v"`
+	}
+	if err := bson.UnmarshalExtJSON(doc, true, &wrapper); err != nil {
+		return err
+	}
+	*id = wrapper.V
+	return nil
+}
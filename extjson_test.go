@@ -0,0 +1,73 @@
+package idx
+
+import (
+	"encoding/base64"
+	"fmt"
+	"go.mongodb.org/mongo-driver/bson"
+	"testing"
+)
+
+func TestID_MarshalExtJSON(t *testing.T) {
+	id := NewID()
+	b, err := id.MarshalExtJSON()
+	if err != nil {
+		t.Fatalf("Got error while marshaling to Extended JSON %v", err)
+	}
+	expected := fmt.Sprintf(`{"$binary":{"base64":"%s","subType":"04"}}`, base64.StdEncoding.EncodeToString(id[:]))
+	if string(b) != expected {
+		t.Fatalf("Extended JSON %s did not match with expectation %s", string(b), expected)
+	}
+
+	// The driver must produce the same representation for documents holding an ID
+	doc, err := bson.MarshalExtJSON(bson.M{"_id": id}, true, false)
+	if err != nil {
+		t.Fatalf("Got error while marshaling document to Extended JSON %v", err)
+	}
+	if string(doc) != `{"_id":`+expected+`}` {
+		t.Fatalf("Driver Extended JSON %s did not match with expectation %s", string(doc), expected)
+	}
+
+	b, err = id.MarshalExtJSONUUID()
+	if err != nil {
+		t.Fatalf("Got error while marshaling to Extended JSON %v", err)
+	}
+	var unm ID
+	if err = unm.UnmarshalExtJSON(b); err != nil {
+		t.Fatalf("Got error while unmarshaling %s: %v", string(b), err)
+	}
+	if unm != id {
+		t.Fatalf("Original ID (%s) did not match with the ID from Extended JSON %s", id.String(), unm.String())
+	}
+}
+
+func TestID_UnmarshalExtJSON(t *testing.T) {
+	id := NewID()
+	encoded := base64.StdEncoding.EncodeToString(id[:])
+	valid := []string{
+		fmt.Sprintf(`{"$binary":{"base64":"%s","subType":"04"}}`, encoded),
+		fmt.Sprintf(`{"$binary":{"base64":"%s","subType":"00"}}`, encoded),
+		fmt.Sprintf(`{"$binary":"%s","$type":"04"}`, encoded),
+		fmt.Sprintf(`"%s"`, id.String()),
+	}
+	for _, val := range valid {
+		var unm ID
+		if err := unm.UnmarshalExtJSON([]byte(val)); err != nil {
+			t.Fatalf("Got error while unmarshaling %s: %v", val, err)
+		}
+		if unm != id {
+			t.Fatalf("Original ID (%s) did not match with the ID from Extended JSON %s", id.String(), unm.String())
+		}
+	}
+	invalid := []string{
+		`{"$uuid":"not-a-uuid"}`,
+		fmt.Sprintf(`{"$binary":{"base64":"%s","subType":"05"}}`, encoded),
+		`"wrong"`,
+		`{`,
+	}
+	for _, val := range invalid {
+		var unm ID
+		if err := unm.UnmarshalExtJSON([]byte(val)); err == nil {
+			t.Fatalf("Was expecting error for %s, but there was no error", val)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Package fiberidx binds fiber (https://pkg.go.dev/github.com/gofiber/fiber/v2) route parameters to idx.ID
+// values.
+package fiberidx
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ieshan/idx"
+)
+
+// Param parses the route parameter name of c. When it is missing or invalid, Param returns a *fiber.Error
+// with status 400 and the message of the *idx.ParamError, so the handler can return it as is.
+func Param(c *fiber.Ctx, name string) (idx.ID, error) {
+	id, err := idx.ParseParam(name, c.Params(name))
+	if err != nil {
+		var paramErr *idx.ParamError
+		errors.As(err, &paramErr)
+		return idx.NilID, fiber.NewError(paramErr.StatusCode(), err.Error())
+	}
+	return id, nil
+}
@@ -0,0 +1,39 @@
+package fiberidx
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ieshan/idx"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParam(t *testing.T) {
+	var got idx.ID
+	app := fiber.New()
+	app.Get("/items/:id", func(c *fiber.Ctx) error {
+		id, err := Param(c, "id")
+		if err != nil {
+			return err
+		}
+		got = id
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	id := idx.NewID()
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/items/"+id.String(), nil))
+	if err != nil || resp.StatusCode != http.StatusNoContent || got != id {
+		t.Fatalf("Parsed ID (%s) did not match with %s: %v", got.String(), id.String(), err)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/items/invalid", nil))
+	if err != nil {
+		t.Fatalf("Got error while sending request %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	expected := `idx: path parameter id: "invalid" is not a valid id`
+	if resp.StatusCode != http.StatusBadRequest || string(body) != expected {
+		t.Fatalf("Was expecting status 400 and %s, got %d and %s", expected, resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,189 @@
+// Package firestoreidx uses idx IDs as Firestore (https://pkg.go.dev/cloud.google.com/go/firestore)
+// document names and struct fields. The client stores the [16]byte array behind idx.ID as an array of
+// integers and has no hook for custom types, so structs holding IDs are converted with Data and DataTo.
+package firestoreidx
+
+import (
+	"cloud.google.com/go/firestore"
+	"fmt"
+	"github.com/ieshan/idx"
+	"reflect"
+)
+
+var (
+	tID      = reflect.TypeOf(idx.ID{})
+	tIDPtr   = reflect.TypeOf((*idx.ID)(nil))
+	tIDSlice = reflect.TypeOf([]idx.ID(nil))
+)
+
+// DocumentID returns the document name of id, its ULID string. It holds no forward slash, is far below
+// the 1500-byte limit and never matches the reserved __.*__ names, and documents listed by name come
+// back in creation order.
+func DocumentID(id idx.ID) string {
+	return id.String()
+}
+
+// FromDocumentID parses a document name returned by DocumentID.
+func FromDocumentID(name string) (idx.ID, error) {
+	return idx.FromString(name)
+}
+
+// Doc returns the reference of the document of id in c.
+func Doc(c *firestore.CollectionRef, id idx.ID) *firestore.DocumentRef {
+	return c.Doc(DocumentID(id))
+}
+
+// RefID returns the ID of a document named by DocumentID.
+func RefID(ref *firestore.DocumentRef) (idx.ID, error) {
+	return FromDocumentID(ref.ID)
+}
+
+// Data returns a copy of the struct or struct pointer src whose top-level idx.ID, *idx.ID and []idx.ID
+// fields are ULID strings, to be passed to DocumentRef.Set, Create or WriteBatch.Set:
+//
+//	data, err := firestoreidx.Data(&order)
+//	_, err = firestoreidx.Doc(client.Collection("orders"), order.ID).Set(ctx, data)
+//
+// firestore struct tags are kept, and a nil *idx.ID is stored as null.
+func Data(src interface{}) (interface{}, error) {
+	v := reflect.Indirect(reflect.ValueOf(src))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("firestoreidx: %T is not a struct", src)
+	}
+	dst := reflect.New(shadowType(v.Type())).Elem()
+	j := 0
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).IsExported() {
+			continue
+		}
+		dst.Field(j).Set(convert(v.Field(i)))
+		j++
+	}
+	return dst.Interface(), nil
+}
+
+// DataTo is like DocumentSnapshot.DataTo, but reads the ULID strings written by Data into the top-level
+// ID fields of the struct pointer dst.
+func DataTo(snap *firestore.DocumentSnapshot, dst interface{}) error {
+	return dataTo(snap.DataTo, dst)
+}
+
+// dataTo loads a shadow struct of dst with load and copies it into dst.
+func dataTo(load func(interface{}) error, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("firestoreidx: %T is not a struct pointer", dst)
+	}
+	v = v.Elem()
+	shadow := reflect.New(shadowType(v.Type()))
+	if err := load(shadow.Interface()); err != nil {
+		return err
+	}
+	j := 0
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		value := shadow.Elem().Field(j)
+		j++
+		if err := set(v.Field(i), value); err != nil {
+			return fmt.Errorf("firestoreidx: field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// shadowType returns t with its exported ID fields replaced by strings. Unexported fields are dropped,
+// as Firestore ignores them.
+func shadowType(t reflect.Type) reflect.Type {
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		switch f.Type {
+		case tID:
+			f.Type = reflect.TypeOf("")
+		case tIDPtr:
+			f.Type = reflect.TypeOf((*string)(nil))
+		case tIDSlice:
+			f.Type = reflect.TypeOf([]string(nil))
+		}
+		fields = append(fields, reflect.StructField{Name: f.Name, Type: f.Type, Tag: f.Tag, Anonymous: f.Anonymous})
+	}
+	return reflect.StructOf(fields)
+}
+
+// convert returns the value of v in its shadow type.
+func convert(v reflect.Value) reflect.Value {
+	switch v.Type() {
+	case tID:
+		return reflect.ValueOf(v.Interface().(idx.ID).String())
+	case tIDPtr:
+		id := v.Interface().(*idx.ID)
+		if id == nil {
+			return reflect.Zero(reflect.TypeOf((*string)(nil)))
+		}
+		s := id.String()
+		return reflect.ValueOf(&s)
+	case tIDSlice:
+		ids := v.Interface().([]idx.ID)
+		if ids == nil {
+			return reflect.Zero(reflect.TypeOf([]string(nil)))
+		}
+		values := make([]string, len(ids))
+		for i, id := range ids {
+			values[i] = id.String()
+		}
+		return reflect.ValueOf(values)
+	}
+	return v
+}
+
+// set stores the shadow value in f. Empty strings are read as idx.NilID.
+func set(f reflect.Value, value reflect.Value) error {
+	switch f.Type() {
+	case tID:
+		id, err := parse(value.String())
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(id))
+	case tIDPtr:
+		if value.IsNil() {
+			f.Set(reflect.Zero(tIDPtr))
+			return nil
+		}
+		id, err := parse(value.Elem().String())
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(&id))
+	case tIDSlice:
+		if value.IsNil() {
+			f.Set(reflect.Zero(tIDSlice))
+			return nil
+		}
+		ids := make([]idx.ID, value.Len())
+		for i := range ids {
+			id, err := parse(value.Index(i).String())
+			if err != nil {
+				return err
+			}
+			ids[i] = id
+		}
+		f.Set(reflect.ValueOf(ids))
+	default:
+		f.Set(value)
+	}
+	return nil
+}
+
+func parse(s string) (idx.ID, error) {
+	if s == "" {
+		return idx.NilID, nil
+	}
+	return idx.FromString(s)
+}
@@ -0,0 +1,76 @@
+package firestoreidx
+
+import (
+	"encoding/json"
+	"github.com/ieshan/idx"
+	"reflect"
+	"testing"
+)
+
+type Order struct {
+	ID       idx.ID   `firestore:"id" json:"id"`
+	ParentID *idx.ID  `firestore:"parent_id" json:"parent_id"`
+	TagIDs   []idx.ID `firestore:"tag_ids" json:"tag_ids"`
+	Amount   int      `firestore:"amount" json:"amount"`
+	internal string
+}
+
+func TestDocumentID(t *testing.T) {
+	id := idx.NewID()
+	name := DocumentID(id)
+	parsed, err := FromDocumentID(name)
+	if err != nil || parsed != id {
+		t.Fatalf("Original ID (%s) did not match with the ID from document name %s: %v", id.String(), parsed.String(), err)
+	}
+	if _, err = FromDocumentID("orders/" + name); err == nil {
+		t.Fatalf("Was expecting error for invalid document name")
+	}
+}
+
+func TestData(t *testing.T) {
+	parent := idx.NewID()
+	order := Order{ID: idx.NewID(), ParentID: &parent, TagIDs: []idx.ID{idx.NewID()}, Amount: 42, internal: "x"}
+	data, err := Data(&order)
+	if err != nil {
+		t.Fatalf("Got error while converting struct %v", err)
+	}
+	v := reflect.ValueOf(data)
+	if v.NumField() != 4 || v.Field(0).String() != order.ID.String() || v.Field(1).Elem().String() != parent.String() {
+		t.Fatalf("Unexpected converted struct %+v", data)
+	}
+	if tag := v.Type().Field(2).Tag.Get("firestore"); tag != "tag_ids" {
+		t.Fatalf("Firestore tag %q was not kept", tag)
+	}
+
+	// The snapshot is emulated with a JSON round trip of the converted struct.
+	b, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Got error while encoding converted struct %v", err)
+	}
+	var result Order
+	if err = dataTo(func(dst interface{}) error { return json.Unmarshal(b, dst) }, &result); err != nil {
+		t.Fatalf("Got error while loading struct %v", err)
+	}
+	order.internal = ""
+	if !reflect.DeepEqual(result, order) {
+		t.Fatalf("Loaded struct %+v did not match with %+v", result, order)
+	}
+
+	empty, _ := Data(Order{})
+	if b, err = json.Marshal(empty); err != nil {
+		t.Fatalf("Got error while encoding converted struct %v", err)
+	}
+	if err = dataTo(func(dst interface{}) error { return json.Unmarshal(b, dst) }, &result); err != nil {
+		t.Fatalf("Got error while loading struct %v", err)
+	}
+	if !reflect.DeepEqual(result, Order{}) {
+		t.Fatalf("Loaded struct %+v was expected to be empty", result)
+	}
+
+	if _, err = Data("order"); err == nil {
+		t.Fatalf("Was expecting error for non-struct value")
+	}
+	if err = dataTo(func(interface{}) error { return nil }, result); err == nil {
+		t.Fatalf("Was expecting error for non-pointer value")
+	}
+}
@@ -0,0 +1,74 @@
+// Package flatbuffersidx stores idx.ID values in FlatBuffers as the 16-byte struct defined in idx.fbs:
+//
+//	struct ID {
+//	  bytes:[ubyte:16];
+//	}
+//
+// Structs are stored inline, so table fields of this type cost no more than the raw bytes.
+package flatbuffersidx
+
+import (
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/ieshan/idx"
+)
+
+// Size is the size of the ID struct in bytes. Its alignment is 1.
+const Size = len(idx.NilID)
+
+// CreateID writes id inline into b and returns its offset, like the Create function flatc generates for
+// structs. Inside a table it must be called right before PrependStructSlot; AddID does both.
+func CreateID(b *flatbuffers.Builder, id idx.ID) flatbuffers.UOffsetT {
+	b.Prep(1, Size)
+	for i := Size - 1; i >= 0; i-- {
+		b.PrependByte(id[i])
+	}
+	return b.Offset()
+}
+
+// AddID sets the ID field with the given slot number of the table being built. NilID is treated as the
+// default value and not written, matching how flatc handles absent struct fields.
+func AddID(b *flatbuffers.Builder, slot int, id idx.ID) {
+	if id == idx.NilID {
+		return
+	}
+	b.PrependStructSlot(slot, CreateID(b, id), 0)
+}
+
+// ReadID returns the ID struct stored at pos in buf.
+func ReadID(buf []byte, pos flatbuffers.UOffsetT) idx.ID {
+	return idx.ID(buf[pos : pos+flatbuffers.UOffsetT(Size)])
+}
+
+// GetID returns the ID field at vtableOffset of t, and whether it is present. vtableOffset is the value
+// generated accessors pass to Table.Offset, 4 for the first field and increasing by 2.
+func GetID(t *flatbuffers.Table, vtableOffset flatbuffers.VOffsetT) (idx.ID, bool) {
+	o := flatbuffers.UOffsetT(t.Offset(vtableOffset))
+	if o == 0 {
+		return idx.NilID, false
+	}
+	return ReadID(t.Bytes, t.Pos+o), true
+}
+
+// CreateIDVector writes ids as a vector of ID structs and returns its offset, for fields declared as
+// [ID].
+func CreateIDVector(b *flatbuffers.Builder, ids []idx.ID) flatbuffers.UOffsetT {
+	b.StartVector(Size, len(ids), 1)
+	for i := len(ids) - 1; i >= 0; i-- {
+		CreateID(b, ids[i])
+	}
+	return b.EndVector(len(ids))
+}
+
+// GetIDVector returns the [ID] vector field at vtableOffset of t, or nil when it is absent.
+func GetIDVector(t *flatbuffers.Table, vtableOffset flatbuffers.VOffsetT) []idx.ID {
+	o := flatbuffers.UOffsetT(t.Offset(vtableOffset))
+	if o == 0 {
+		return nil
+	}
+	start := t.Vector(o)
+	ids := make([]idx.ID, t.VectorLen(o))
+	for i := range ids {
+		ids[i] = ReadID(t.Bytes, start+flatbuffers.UOffsetT(i*Size))
+	}
+	return ids
+}
@@ -0,0 +1,60 @@
+package flatbuffersidx
+
+import (
+	flatbuffers "github.com/google/flatbuffers/go"
+	"github.com/ieshan/idx"
+	"slices"
+	"testing"
+)
+
+// buildEvent builds a table equivalent to:
+//
+//	table Event {
+//	  id:ID;
+//	  parent:ID;
+//	  links:[ID];
+//	}
+func buildEvent(id, parent idx.ID, links []idx.ID) []byte {
+	b := flatbuffers.NewBuilder(0)
+	vec := CreateIDVector(b, links)
+	b.StartObject(3)
+	AddID(b, 0, id)
+	AddID(b, 1, parent)
+	b.PrependUOffsetTSlot(2, vec, 0)
+	b.Finish(b.EndObject())
+	return b.FinishedBytes()
+}
+
+func TestAddID(t *testing.T) {
+	id := idx.NewID()
+	links := []idx.ID{idx.NewID(), idx.NewID()}
+	buf := buildEvent(id, idx.NilID, links)
+
+	tab := &flatbuffers.Table{Bytes: buf, Pos: flatbuffers.GetUOffsetT(buf)}
+	decoded, ok := GetID(tab, 4)
+	if !ok || decoded != id {
+		t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+	}
+	if decoded, ok = GetID(tab, 6); ok || decoded != idx.NilID {
+		t.Fatalf("Was expecting absent parent, got %s", decoded.String())
+	}
+	if result := GetIDVector(tab, 8); !slices.Equal(result, links) {
+		t.Fatalf("Decoded links %v did not match with %v", result, links)
+	}
+	if result := GetIDVector(tab, 10); result != nil {
+		t.Fatalf("Was expecting nil vector, got %v", result)
+	}
+}
+
+func TestCreateID(t *testing.T) {
+	id := idx.NewID()
+	b := flatbuffers.NewBuilder(0)
+	off := CreateID(b, id)
+	buf := b.Bytes[b.Head():]
+	if len(buf) != Size {
+		t.Fatalf("Struct took %d bytes, was expecting %d", len(buf), Size)
+	}
+	if decoded := ReadID(b.Bytes, flatbuffers.UOffsetT(len(b.Bytes))-off); decoded != id {
+		t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+	}
+}
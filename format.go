@@ -0,0 +1,51 @@
+package idx
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"strconv"
+	"strings"
+)
+
+// debugTimeLayout is the timestamp layout used by the %v and %+v verbs.
+const debugTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// Format implements fmt.Formatter. %s prints the ULID string and %q the quoted ULID string, %x and %X
+// print the 16 bytes in lower and upper-case hexadecimal, %v prints the ULID followed by its creation
+// time and %+v additionally prints the entropy bytes. Width and the '-' flag are honoured.
+func (id ID) Format(f fmt.State, verb rune) {
+	var buf []byte
+	switch verb {
+	case 's':
+		buf = id.AppendString(make([]byte, 0, ulid.EncodedSize))
+	case 'q':
+		buf = strconv.AppendQuote(nil, id.String())
+	case 'x':
+		buf = hex.AppendEncode(nil, id[:])
+	case 'X':
+		buf = []byte(strings.ToUpper(hex.EncodeToString(id[:])))
+	case 'v':
+		buf = id.AppendString(make([]byte, 0, 64))
+		buf = append(buf, " ("...)
+		buf = id.Time().AppendFormat(buf, debugTimeLayout)
+		if f.Flag('+') {
+			buf = append(buf, ", entropy "...)
+			buf = hex.AppendEncode(buf, id[6:])
+		}
+		buf = append(buf, ')')
+	default:
+		fmt.Fprintf(f, "%%!%c(idx.ID=%s)", verb, id.String())
+		return
+	}
+	if width, ok := f.Width(); ok && width > len(buf) {
+		pad := bytes.Repeat([]byte{' '}, width-len(buf))
+		if f.Flag('-') {
+			buf = append(buf, pad...)
+		} else {
+			buf = append(pad, buf...)
+		}
+	}
+	_, _ = f.Write(buf)
+}
@@ -0,0 +1,47 @@
+package idx
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestID_Time(t *testing.T) {
+	id := NewID()
+	if since := time.Since(id.Time()); since < 0 || since > time.Minute {
+		t.Fatalf("ID time %v is not close to now", id.Time())
+	}
+	if id.Time().Location() != time.UTC {
+		t.Fatalf("ID time %v is not in UTC", id.Time())
+	}
+}
+
+func TestID_Format(t *testing.T) {
+	id, err := FromString("01HAK8JPF7S0SFMJ2X96W37WXB")
+	if err != nil {
+		t.Fatalf("Got error while parsing ID %v", err)
+	}
+	ts := id.Time().Format(debugTimeLayout)
+	for format, expected := range map[string]string{
+		"%s":    id.String(),
+		"%q":    `"` + id.String() + `"`,
+		"%x":    id.Hex(),
+		"%X":    strings.ToUpper(id.Hex()),
+		"%v":    id.String() + " (" + ts + ")",
+		"%+v":   id.String() + " (" + ts + ", entropy " + id.Hex()[12:] + ")",
+		"%28s":  "  " + id.String(),
+		"%-28s": id.String() + "  ",
+		"%d":    "%!d(idx.ID=" + id.String() + ")",
+	} {
+		if result := fmt.Sprintf(format, id); result != expected {
+			t.Fatalf("Formatted value %q for %s did not match with %q", result, format, expected)
+		}
+	}
+	if result := fmt.Sprintf("%s", &id); result != id.String() {
+		t.Fatalf("Formatted pointer %q did not match with %q", result, id.String())
+	}
+	if !strings.HasPrefix(fmt.Sprintf("%v", id), "01HAK8JPF7S0SFMJ2X96W37WXB (2023-") {
+		t.Fatalf("Debug form %v does not contain the timestamp", id)
+	}
+}
@@ -0,0 +1,23 @@
+// Package ginidx binds gin (https://pkg.go.dev/github.com/gin-gonic/gin) path parameters to idx.ID values.
+package ginidx
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/ieshan/idx"
+)
+
+// Param parses the path parameter name of c. When it is missing or invalid, Param aborts c with status 400
+// and a {"error": message} JSON body, records the *idx.ParamError with c.Error and returns false, so the
+// handler only has to return.
+func Param(c *gin.Context, name string) (idx.ID, bool) {
+	id, err := idx.ParseParam(name, c.Param(name))
+	if err != nil {
+		var paramErr *idx.ParamError
+		errors.As(err, &paramErr)
+		_ = c.Error(err)
+		c.AbortWithStatusJSON(paramErr.StatusCode(), gin.H{"error": err.Error()})
+		return idx.NilID, false
+	}
+	return id, true
+}
@@ -0,0 +1,37 @@
+package ginidx
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/ieshan/idx"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParam(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var got idx.ID
+	router := gin.New()
+	router.GET("/items/:id", func(c *gin.Context) {
+		id, ok := Param(c, "id")
+		if !ok {
+			return
+		}
+		got = id
+		c.Status(http.StatusNoContent)
+	})
+
+	id := idx.NewID()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/"+id.String(), nil))
+	if w.Code != http.StatusNoContent || got != id {
+		t.Fatalf("Parsed ID (%s) did not match with %s, status %d", got.String(), id.String(), w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items/invalid", nil))
+	expected := `{"error":"idx: path parameter id: \"invalid\" is not a valid id"}`
+	if w.Code != http.StatusBadRequest || w.Body.String() != expected {
+		t.Fatalf("Was expecting status 400 and %s, got %d and %s", expected, w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,320 @@
+// Package gormidx integrates idx.ID fields with GORM (https://pkg.go.dev/gorm.io/gorm): column types for
+// AutoMigrate, a plugin assigning primary keys, keyset pagination and time range scopes, and a serializer
+// choosing the storage format per field.
+package gormidx
+
+import (
+	"context"
+	"fmt"
+	"github.com/ieshan/idx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	tID          = reflect.TypeOf(idx.ID{})
+	tSwappedID   = reflect.TypeOf(idx.SwappedID{})
+	tSQLServerID = reflect.TypeOf(idx.SQLServerID{})
+	tUUID        = reflect.TypeOf(idx.UUID{})
+)
+
+func init() {
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+// DataTypeOf returns the column type matching what field stores on the dialect of db: BINARY(16) for
+// MySQL, MariaDB and SQL Server, bytea for PostgreSQL and BLOB for SQLite for ID and SwappedID fields,
+// uuid on PostgreSQL and CHAR(36) elsewhere for UUID fields, and uniqueidentifier for SQLServerID fields.
+// ID fields using the idx serializer get a column for the format they select. Other fields and dialects
+// return "", leaving the type to the dialect.
+func DataTypeOf(db *gorm.DB, field *schema.Field) string {
+	dialect := db.Dialector.Name()
+	switch field.IndirectFieldType {
+	case tID:
+		switch format(field) {
+		case FormatText:
+			return "CHAR(26)"
+		case FormatUUID:
+			if dialect == "postgres" {
+				return "uuid"
+			}
+			return "CHAR(36)"
+		}
+	case tSwappedID:
+	case tUUID:
+		if dialect == "postgres" {
+			return "uuid"
+		}
+		return "CHAR(36)"
+	case tSQLServerID:
+		return "uniqueidentifier"
+	default:
+		return ""
+	}
+	switch dialect {
+	case "mysql", "sqlserver":
+		return "BINARY(16)"
+	case "postgres":
+		return "bytea"
+	case "sqlite":
+		return "BLOB"
+	}
+	return ""
+}
+
+// AutoMigrate is db.AutoMigrate creating the columns of ID fields with the types of DataTypeOf:
+//
+//	err := gormidx.AutoMigrate(db, &Order{}, &Customer{})
+//
+// The types are set on the schemas cached by db, so later migrations of the same models through db, such
+// as Migrator().CreateTable, use them as well. Fields with a type tag setting keep it.
+func AutoMigrate(db *gorm.DB, models ...interface{}) error {
+	if err := setDataTypes(db, models...); err != nil {
+		return err
+	}
+	return db.AutoMigrate(models...)
+}
+
+// setDataTypes sets the DataType of the ID fields of models to DataTypeOf. Dialects write unknown data
+// types as is, which is how GORM applies type tag settings.
+func setDataTypes(db *gorm.DB, models ...interface{}) error {
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		for _, field := range stmt.Schema.Fields {
+			if _, ok := field.TagSettings["TYPE"]; ok {
+				continue
+			}
+			if dataType := DataTypeOf(db, field); dataType != "" {
+				field.DataType = schema.DataType(dataType)
+			}
+		}
+	}
+	return nil
+}
+
+// Plugin fills zero-valued ID primary keys with idx.NewID before records are created, the way gorm
+// leaves auto-increment keys to the database:
+//
+//	db.Use(gormidx.Plugin{})
+//
+// Both ID and *ID primary keys are filled, including when a slice of records is created at once.
+type Plugin struct{}
+
+// Name implements gorm.Plugin.
+func (Plugin) Name() string {
+	return "idx:assign_id"
+}
+
+// Initialize implements gorm.Plugin.
+func (p Plugin) Initialize(db *gorm.DB) error {
+	return db.Callback().Create().Before("gorm:create").Register(p.Name(), assignIDs)
+}
+
+// assignIDs sets every zero-valued ID primary key of the records being created.
+func assignIDs(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	var fields []*schema.Field
+	for _, field := range db.Statement.Schema.PrimaryFields {
+		if field.IndirectFieldType == tID {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	ctx, rv := db.Statement.Context, db.Statement.ReflectValue
+	assign := func(rv reflect.Value) {
+		for _, field := range fields {
+			if _, isZero := field.ValueOf(ctx, rv); isZero {
+				db.AddError(field.Set(ctx, rv, idx.NewID()))
+			}
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+				assign(elem)
+			}
+		}
+	case reflect.Struct:
+		assign(rv)
+	}
+}
+
+// After returns a GORM scope selecting rows whose column is greater than id. Binary ID columns sort in
+// creation order, so this replaces OFFSET paging with an index range scan.
+func After(column string, id idx.ID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Gt{Column: clause.Column{Name: column}, Value: id})
+	}
+}
+
+// Before returns a GORM scope selecting rows whose column is less than id.
+func Before(column string, id idx.ID) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Lt{Column: clause.Column{Name: column}, Value: id})
+	}
+}
+
+// PageBy returns a GORM scope selecting the next limit rows after cursor, oldest first. The ID of the last
+// row is the cursor of the following page; idx.NilID starts from the beginning.
+//
+//	var orders []Order
+//	db.Scopes(gormidx.PageBy("id", cursor, 50)).Find(&orders)
+func PageBy(column string, cursor idx.ID, limit int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if cursor != idx.NilID {
+			db = db.Scopes(After(column, cursor))
+		}
+		return db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}}).Limit(limit)
+	}
+}
+
+// PageByDesc is like PageBy but walks from the newest rows backwards, selecting the limit rows before
+// cursor. idx.NilID starts from the newest row.
+func PageByDesc(column string, cursor idx.ID, limit int) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if cursor != idx.NilID {
+			db = db.Scopes(Before(column, cursor))
+		}
+		return db.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: true}).Limit(limit)
+	}
+}
+
+// CreatedBetween returns a GORM scope selecting rows whose ID column was generated in [from, to), using
+// the timestamp embedded in the IDs instead of a created_at column. Bounds have millisecond precision.
+// Times before 1970, such as the zero time.Time, bind the zero ID: as from they leave the range unbounded
+// below, as to they match no rows.
+func CreatedBetween(column string, from, to time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(CreatedAfter(column, from), CreatedBefore(column, to))
+	}
+}
+
+// CreatedAfter returns a GORM scope selecting rows whose ID column was generated at or after t.
+func CreatedAfter(column string, t time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Gte{Column: clause.Column{Name: column}, Value: idx.RangeBound(idx.MinIDAt(t))})
+	}
+}
+
+// CreatedBefore returns a GORM scope selecting rows whose ID column was generated before t.
+func CreatedBefore(column string, t time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(clause.Lt{Column: clause.Column{Name: column}, Value: idx.RangeBound(idx.MinIDAt(t))})
+	}
+}
+
+// SerializerName is the name the Serializer is registered under.
+const SerializerName = "idx"
+
+// Formats selected with the idxformat tag setting of fields using the idx serializer.
+const (
+	// FormatBinary stores the 16 ID bytes, like ID.Value.
+	FormatBinary = "binary"
+	// FormatText stores the 26-character ULID string.
+	FormatText = "text"
+	// FormatUUID stores the canonical UUID string, accepted by native uuid columns.
+	FormatUUID = "uuid"
+)
+
+// Serializer stores ID and *ID fields in the format chosen per field with the idxformat tag setting, so
+// each column of a legacy schema can keep its representation:
+//
+//	type Order struct {
+//		ID       idx.ID  `gorm:"primaryKey;serializer:idx"`
+//		LegacyID idx.ID  `gorm:"serializer:idx;idxformat:text"`
+//		TraceID  *idx.ID `gorm:"serializer:idx;idxformat:uuid"`
+//	}
+//
+// The binary format is used when idxformat is not set. Scan accepts every format regardless of the
+// setting, and NilID is stored as NULL. It is registered as "idx" when the package is imported.
+type Serializer struct{}
+
+// Scan implements schema.SerializerInterface.
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType)
+	if dbValue != nil {
+		var id idx.ID
+		var err error
+		switch v := dbValue.(type) {
+		case []byte:
+			if len(v) == len(id) {
+				id = idx.ID(v)
+			} else {
+				id, err = parse(string(v))
+			}
+		case string:
+			id, err = parse(v)
+		default:
+			err = fmt.Errorf("gormidx: cannot scan %T into field %s", dbValue, field.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if field.FieldType.Kind() == reflect.Pointer {
+			fieldValue.Elem().Set(reflect.ValueOf(&id))
+		} else {
+			fieldValue.Elem().Set(reflect.ValueOf(id))
+		}
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+// Value implements schema.SerializerValuerInterface.
+func (Serializer) Value(_ context.Context, field *schema.Field, _ reflect.Value, fieldValue interface{}) (interface{}, error) {
+	var id idx.ID
+	switch v := fieldValue.(type) {
+	case idx.ID:
+		id = v
+	case *idx.ID:
+		if v != nil {
+			id = *v
+		}
+	default:
+		return nil, fmt.Errorf("gormidx: invalid field type %T for the idx serializer", fieldValue)
+	}
+	if id == idx.NilID {
+		return nil, nil
+	}
+	switch format(field) {
+	case FormatText:
+		return id.String(), nil
+	case FormatUUID:
+		return id.UUIDString(), nil
+	}
+	return id[:], nil
+}
+
+// format returns the storage format of field, which is FormatBinary unless it uses the idx serializer
+// with an idxformat setting.
+func format(field *schema.Field) string {
+	if field == nil || !strings.EqualFold(field.TagSettings["SERIALIZER"], SerializerName) {
+		return FormatBinary
+	}
+	switch format := strings.ToLower(field.TagSettings["IDXFORMAT"]); format {
+	case FormatText, FormatUUID:
+		return format
+	}
+	return FormatBinary
+}
+
+// parse parses val as a ULID string or, based on its length, as the hexadecimal or UUID form.
+func parse(val string) (idx.ID, error) {
+	switch len(val) {
+	case idx.HexEncodedSize, len("00000000-0000-0000-0000-000000000000"):
+		return idx.FromHex(val)
+	}
+	return idx.FromString(val)
+}
@@ -0,0 +1,267 @@
+package gormidx
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"github.com/ieshan/idx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gormDialector is a gorm.Dialector that only reports its name, for dry-run sessions. Like the GORM
+// dialects, it writes unknown data types as is.
+type gormDialector string
+
+func (d gormDialector) Name() string {
+	return string(d)
+}
+
+func (gormDialector) Initialize(db *gorm.DB) error {
+	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{})
+	return nil
+}
+
+func (d gormDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return migrator.Migrator{Config: migrator.Config{DB: db, Dialector: d}}
+}
+func (gormDialector) DataTypeOf(field *schema.Field) string          { return string(field.DataType) }
+func (gormDialector) DefaultValueOf(*schema.Field) clause.Expression { return nil }
+func (gormDialector) BindVarTo(w clause.Writer, _ *gorm.Statement, _ any) {
+	_ = w.WriteByte('?')
+}
+
+func (gormDialector) QuoteTo(w clause.Writer, str string) {
+	_, _ = w.WriteString("`" + str + "`")
+}
+func (gormDialector) Explain(sql string, _ ...any) string { return sql }
+
+func TestDataTypeOf(t *testing.T) {
+	type IdTestStruct struct {
+		ID        idx.ID          `gorm:"column:id;primaryKey"`
+		FkID      *idx.ID         `gorm:"column:fk_id"`
+		SwappedID idx.SwappedID   `gorm:"column:swapped_id"`
+		UUID      idx.UUID        `gorm:"column:uuid"`
+		GUID      idx.SQLServerID `gorm:"column:guid"`
+		Value     string          `gorm:"column:value"`
+	}
+	s, err := schema.Parse(&IdTestStruct{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("Got error while parsing schema %v", err)
+	}
+	for column, dataType := range map[string]schema.DataType{"id": schema.Bytes, "fk_id": schema.Bytes, "uuid": schema.String} {
+		if field := s.LookUpField(column); field.DataType != dataType {
+			t.Fatalf("Field %s has data type %s, was expecting %s", column, field.DataType, dataType)
+		}
+	}
+
+	expected := map[string]map[string]string{
+		"mysql":      {"id": "BINARY(16)", "fk_id": "BINARY(16)", "swapped_id": "BINARY(16)", "uuid": "CHAR(36)", "guid": "uniqueidentifier"},
+		"postgres":   {"id": "bytea", "fk_id": "bytea", "swapped_id": "bytea", "uuid": "uuid", "guid": "uniqueidentifier"},
+		"sqlite":     {"id": "BLOB", "fk_id": "BLOB", "swapped_id": "BLOB", "uuid": "CHAR(36)", "guid": "uniqueidentifier"},
+		"sqlserver":  {"id": "BINARY(16)", "fk_id": "BINARY(16)", "swapped_id": "BINARY(16)", "uuid": "CHAR(36)", "guid": "uniqueidentifier"},
+		"clickhouse": {"id": "", "fk_id": "", "swapped_id": "", "uuid": "CHAR(36)", "guid": "uniqueidentifier"},
+	}
+	for name, types := range expected {
+		db := &gorm.DB{Config: &gorm.Config{Dialector: gormDialector(name)}}
+		types["value"] = ""
+		for column, dataType := range types {
+			if result := DataTypeOf(db, s.LookUpField(column)); result != dataType {
+				t.Fatalf("Data type %q of %s for %s did not match with %q", result, column, name, dataType)
+			}
+		}
+	}
+}
+
+func TestAutoMigrate(t *testing.T) {
+	type IdTestStruct struct {
+		ID       idx.ID  `gorm:"column:id;primaryKey"`
+		LegacyID idx.ID  `gorm:"column:legacy_id;type:CHAR(32)"`
+		TraceID  *idx.ID `gorm:"column:trace_id;serializer:idx;idxformat:uuid"`
+		Value    string  `gorm:"column:value"`
+	}
+	db, err := gorm.Open(gormDialector("postgres"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	if err = setDataTypes(db, &IdTestStruct{}); err != nil {
+		t.Fatalf("Got error while setting data types %v", err)
+	}
+
+	// The migrator reads the schema cached by db.
+	stmt := &gorm.Statement{DB: db}
+	if err = stmt.Parse(&IdTestStruct{}); err != nil {
+		t.Fatalf("Got error while parsing schema %v", err)
+	}
+	expected := map[string]string{"id": "bytea", "legacy_id": "CHAR(32)", "trace_id": "uuid", "value": "string"}
+	for column, dataType := range expected {
+		if result := db.Migrator().FullDataTypeOf(stmt.Schema.LookUpField(column)).SQL; result != dataType {
+			t.Fatalf("Column type %q for %s did not match with %q", result, column, dataType)
+		}
+	}
+}
+
+func TestPlugin(t *testing.T) {
+	type IdTestStruct struct {
+		ID    idx.ID `gorm:"column:id;primaryKey"`
+		Value string `gorm:"column:value"`
+	}
+	type PtrTestStruct struct {
+		ID   *idx.ID `gorm:"column:id;primaryKey"`
+		FkID idx.ID  `gorm:"column:fk_id"`
+	}
+	db, err := gorm.Open(gormDialector("mysql"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	if err = db.Use(Plugin{}); err != nil {
+		t.Fatalf("Got error while registering plugin %v", err)
+	}
+
+	existing := idx.NewID()
+	records := []IdTestStruct{{Value: "a"}, {ID: existing, Value: "b"}}
+	if err = db.Create(&records).Error; err != nil {
+		t.Fatalf("Got error while creating records %v", err)
+	}
+	if records[0].ID == idx.NilID || records[1].ID != existing {
+		t.Fatalf("Unexpected IDs after create %+v", records)
+	}
+
+	var ptr PtrTestStruct
+	if err = db.Create(&ptr).Error; err != nil {
+		t.Fatalf("Got error while creating record %v", err)
+	}
+	if ptr.ID == nil || *ptr.ID == idx.NilID || ptr.FkID != idx.NilID {
+		t.Fatalf("Unexpected IDs after create %+v", ptr)
+	}
+}
+
+func TestPageBy(t *testing.T) {
+	type IdTestStruct struct {
+		ID    idx.ID `gorm:"column:id;primaryKey"`
+		Value string `gorm:"column:value"`
+	}
+	db, err := gorm.Open(gormDialector("mysql"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	cursor := idx.NewID()
+	queries := map[string]func(*gorm.DB) *gorm.DB{
+		"SELECT * FROM `id_test_structs` WHERE `id` > ? ORDER BY `id` LIMIT ?":      PageBy("id", cursor, 10),
+		"SELECT * FROM `id_test_structs` ORDER BY `id` LIMIT ?":                     PageBy("id", idx.NilID, 10),
+		"SELECT * FROM `id_test_structs` WHERE `id` < ? ORDER BY `id` DESC LIMIT ?": PageByDesc("id", cursor, 5),
+		"SELECT * FROM `id_test_structs` ORDER BY `id` DESC LIMIT ?":                PageByDesc("id", idx.NilID, 5),
+		"SELECT * FROM `id_test_structs` WHERE `id` > ? AND `id` < ?":               func(db *gorm.DB) *gorm.DB { return db.Scopes(After("id", cursor), Before("id", cursor)) },
+	}
+	for expected, scope := range queries {
+		var rows []IdTestStruct
+		stmt := db.Session(&gorm.Session{}).Scopes(scope).Find(&rows).Statement
+		if sql := stmt.SQL.String(); sql != expected {
+			t.Fatalf("Query %q did not match with %q", sql, expected)
+		}
+		for _, v := range stmt.Vars {
+			if id, ok := v.(idx.ID); ok && id != cursor {
+				t.Fatalf("Query variable %v did not match with cursor %s", v, cursor.String())
+			}
+		}
+	}
+}
+
+func TestCreatedBetween(t *testing.T) {
+	type IdTestStruct struct {
+		ID idx.ID `gorm:"column:id;primaryKey"`
+	}
+	db, err := gorm.Open(gormDialector("mysql"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	from, to := time.Now().Add(-time.Hour), time.Now()
+	var rows []IdTestStruct
+	stmt := db.Scopes(CreatedBetween("id", from, to)).Find(&rows).Statement
+	if sql := stmt.SQL.String(); sql != "SELECT * FROM `id_test_structs` WHERE `id` >= ? AND `id` < ?" {
+		t.Fatalf("Unexpected query %q", sql)
+	}
+	if len(stmt.Vars) != 2 || stmt.Vars[0] != idx.RangeBound(idx.MinIDAt(from)) || stmt.Vars[1] != idx.RangeBound(idx.MinIDAt(to)) {
+		t.Fatalf("Unexpected query variables %v", stmt.Vars)
+	}
+
+	// The zero time binds the zero ID instead of NULL.
+	stmt = db.Scopes(CreatedBetween("id", time.Time{}, to)).Find(&rows).Statement
+	if len(stmt.Vars) != 2 {
+		t.Fatalf("Unexpected query variables %v", stmt.Vars)
+	}
+	if value, err := stmt.Vars[0].(driver.Valuer).Value(); err != nil || !bytes.Equal(value.([]byte), idx.NilID[:]) {
+		t.Fatalf("Was expecting the zero ID bound, got %v: %v", value, err)
+	}
+}
+
+func TestSerializer(t *testing.T) {
+	type IdTestStruct struct {
+		ID     idx.ID  `gorm:"column:id;serializer:idx"`
+		TextID idx.ID  `gorm:"column:text_id;serializer:idx;idxformat:text"`
+		UUIDID *idx.ID `gorm:"column:uuid_id;serializer:idx;idxformat:uuid"`
+	}
+	s, err := schema.Parse(&IdTestStruct{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("Got error while parsing schema %v", err)
+	}
+	id, textID, uuidID := idx.NewID(), idx.NewID(), idx.NewID()
+	data := IdTestStruct{ID: id, TextID: textID, UUIDID: &uuidID}
+	expected := map[string]interface{}{"id": id[:], "text_id": textID.String(), "uuid_id": uuidID.UUIDString()}
+
+	ctx := context.Background()
+	var result IdTestStruct
+	dst := reflect.ValueOf(&result).Elem()
+	for column, value := range expected {
+		field := s.LookUpField(column)
+		fieldValue := reflect.ValueOf(data).FieldByName(field.Name).Interface()
+		stored, err := Serializer{}.Value(ctx, field, reflect.ValueOf(data), fieldValue)
+		if err != nil {
+			t.Fatalf("Got error while serializing %s %v", column, err)
+		}
+		if !reflect.DeepEqual(stored, value) {
+			t.Fatalf("Stored value %v for %s did not match with %v", stored, column, value)
+		}
+		if err = (Serializer{}).Scan(ctx, field, dst, stored); err != nil {
+			t.Fatalf("Got error while scanning %s %v", column, err)
+		}
+	}
+	if result.ID != id || result.TextID != textID || result.UUIDID == nil || *result.UUIDID != uuidID {
+		t.Fatalf("Scanned value %+v did not match with original value %+v", result, data)
+	}
+
+	// Scan accepts every format whatever the field setting is.
+	field := s.LookUpField("id")
+	for _, stored := range []interface{}{textID.String(), []byte(textID.UUIDString()), textID.Hex()} {
+		if err = (Serializer{}).Scan(ctx, field, dst, stored); err != nil || result.ID != textID {
+			t.Fatalf("Scanned ID (%s) did not match with %s: %v", result.ID.String(), textID.String(), err)
+		}
+	}
+	if err = (Serializer{}).Scan(ctx, s.LookUpField("uuid_id"), dst, nil); err != nil || result.UUIDID != nil {
+		t.Fatalf("Was expecting nil ID, got %v: %v", result.UUIDID, err)
+	}
+	if stored, err := (Serializer{}).Value(ctx, field, dst, idx.NilID); err != nil || stored != nil {
+		t.Fatalf("Was expecting NULL for NilID, got %v: %v", stored, err)
+	}
+	if err = (Serializer{}).Scan(ctx, field, dst, 42); err == nil {
+		t.Fatalf("Was expecting error for unsupported type")
+	}
+
+	types := map[string]string{"id": "bytea", "text_id": "CHAR(26)", "uuid_id": "uuid"}
+	db := &gorm.DB{Config: &gorm.Config{Dialector: gormDialector("postgres")}}
+	for column, dataType := range types {
+		if result := DataTypeOf(db, s.LookUpField(column)); result != dataType {
+			t.Fatalf("Data type %q for %s did not match with %q", result, column, dataType)
+		}
+	}
+	if serializer, ok := schema.GetSerializer("idx"); !ok || serializer != (Serializer{}) {
+		t.Fatalf("Serializer is not registered")
+	}
+}
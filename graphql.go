@@ -0,0 +1,47 @@
+package idx
+
+import (
+	"fmt"
+	"io"
+)
+
+// GraphQLTypeName is the name of the custom scalar the ID type implements for graph-gophers/graphql-go.
+// The schema must declare it with "scalar ULID".
+const GraphQLTypeName = "ULID"
+
+// MarshalGQL writes the IDX as a quoted ULID string. Together with UnmarshalGQL it allows binding ID
+// directly as a custom gqlgen scalar. See https://gqlgen.com/reference/scalars/
+func (id ID) MarshalGQL(w io.Writer) {
+	b := make([]byte, 0, 28)
+	b = append(b, '"')
+	b = id.AppendString(b)
+	b = append(b, '"')
+	_, _ = w.Write(b)
+}
+
+// UnmarshalGQL populates the IDX from a gqlgen scalar input, which must be a ULID string.
+func (id *ID) UnmarshalGQL(v interface{}) error {
+	switch val := v.(type) {
+	case string:
+		return decodeText(val, id)
+	case []byte:
+		return decodeText(val, id)
+	}
+	return fmt.Errorf("idx: cannot unmarshal %T into an ID", v)
+}
+
+// ImplementsGraphQLType reports whether the ID type implements the named GraphQL type, which allows using ID as
+// a custom scalar with graph-gophers/graphql-go. The output uses MarshalJSON.
+func (ID) ImplementsGraphQLType(name string) bool {
+	return name == GraphQLTypeName
+}
+
+// UnmarshalGraphQL populates the IDX from a graph-gophers/graphql-go scalar input. It accepts the same
+// values as UnmarshalJSON: a ULID string, or an empty string decoded as NilID.
+func (id *ID) UnmarshalGraphQL(input interface{}) error {
+	if str, ok := input.(string); ok && str == "" {
+		*id = NilID
+		return nil
+	}
+	return id.UnmarshalGQL(input)
+}
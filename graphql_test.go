@@ -0,0 +1,72 @@
+package idx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/oklog/ulid/v2"
+	"testing"
+)
+
+func TestID_MarshalGQL(t *testing.T) {
+	id := NewID()
+	var buf bytes.Buffer
+	id.MarshalGQL(&buf)
+	if buf.String() != `"`+id.String()+`"` {
+		t.Fatalf("Original ID (%s) did not match with GraphQL output %s", id.String(), buf.String())
+	}
+}
+
+func TestID_UnmarshalGQL(t *testing.T) {
+	id := NewID()
+	var unm ID
+	if err := unm.UnmarshalGQL(id.String()); err != nil {
+		t.Fatalf("Got error while unmarshaling GraphQL input %v", err)
+	}
+	if unm != id {
+		t.Fatalf("Original ID (%s) did not match with the ID from GraphQL input %s", id.String(), unm.String())
+	}
+	if err := unm.UnmarshalGQL("01HAK8JPF7S0SFMJ2X96W37WXI"); !errors.Is(err, ulid.ErrInvalidCharacters) {
+		t.Fatalf("Was expecting invalid characters error, got %v", err)
+	}
+	if err := unm.UnmarshalGQL(42); err == nil {
+		t.Fatalf("Was expecting error for non string input, but there was no error")
+	}
+}
+
+func TestID_UnmarshalGraphQL(t *testing.T) {
+	if !NilID.ImplementsGraphQLType(GraphQLTypeName) || NilID.ImplementsGraphQLType("ID") {
+		t.Fatalf("ID should only implement the %s GraphQL type", GraphQLTypeName)
+	}
+
+	schema := graphql.MustParseSchema(`
+		scalar ULID
+		type Query {
+			echo(id: ULID!): ULID!
+		}
+	`, &graphqlResolver{})
+	id := NewID()
+	resp := schema.Exec(context.Background(), `query($id: ULID!) { echo(id: $id) }`, "", map[string]interface{}{"id": id.String()})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("Got error while executing query %v", resp.Errors)
+	}
+	if string(resp.Data) != `{"echo":"`+id.String()+`"}` {
+		t.Fatalf("Original ID (%s) did not match with response %s", id.String(), string(resp.Data))
+	}
+	resp = schema.Exec(context.Background(), `{ echo(id: "wrong") }`, "", nil)
+	if len(resp.Errors) == 0 {
+		t.Fatalf("Was expecting error for invalid ID, but there was no error")
+	}
+
+	unm := id
+	if err := unm.UnmarshalGraphQL(""); err != nil || unm != NilID {
+		t.Fatalf("Was expecting NilID without error, got %s %v", unm.String(), err)
+	}
+}
+
+type graphqlResolver struct{}
+
+func (*graphqlResolver) Echo(args struct{ ID ID }) ID {
+	return args.ID
+}
@@ -0,0 +1,50 @@
+// Package graphqlidx provides an idx.ID scalar for github.com/graphql-go/graphql.
+package graphqlidx
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/ieshan/idx"
+)
+
+// Scalar is the ULID scalar. It serializes and parses values the same way idx.ID marshals to and from
+// JSON: a 26-character ULID string, with the empty string standing for idx.NilID.
+var Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:         idx.GraphQLTypeName,
+	Description:  "A ULID encoded as a 26-character Crockford base32 string.",
+	Serialize:    serialize,
+	ParseValue:   parseValue,
+	ParseLiteral: parseLiteral,
+})
+
+func serialize(value interface{}) interface{} {
+	switch val := value.(type) {
+	case idx.ID:
+		return val.String()
+	case *idx.ID:
+		if val == nil {
+			return nil
+		}
+		return val.String()
+	case string:
+		if id, ok := parseValue(val).(idx.ID); ok {
+			return id.String()
+		}
+	}
+	return nil
+}
+
+func parseValue(value interface{}) interface{} {
+	var id idx.ID
+	if err := id.UnmarshalGraphQL(value); err != nil {
+		return nil
+	}
+	return id
+}
+
+func parseLiteral(valueAST ast.Value) interface{} {
+	if val, ok := valueAST.(*ast.StringValue); ok {
+		return parseValue(val.Value)
+	}
+	return nil
+}
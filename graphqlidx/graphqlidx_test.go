@@ -0,0 +1,72 @@
+package graphqlidx
+
+import (
+	"encoding/json"
+	"github.com/graphql-go/graphql"
+	"github.com/ieshan/idx"
+	"testing"
+)
+
+func TestScalar(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"echo": &graphql.Field{
+					Type: Scalar,
+					Args: graphql.FieldConfigArgument{
+						"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(Scalar)},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["id"].(idx.ID), nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Got error while creating schema %v", err)
+	}
+
+	id := idx.NewID()
+	queries := []graphql.Params{
+		{Schema: schema, RequestString: `{ echo(id: "` + id.String() + `") }`},
+		{
+			Schema:         schema,
+			RequestString:  `query($id: ULID!) { echo(id: $id) }`,
+			VariableValues: map[string]interface{}{"id": id.String()},
+		},
+	}
+	for _, params := range queries {
+		result := graphql.Do(params)
+		if result.HasErrors() {
+			t.Fatalf("Got error while executing query %v", result.Errors)
+		}
+		data, err := json.Marshal(result.Data)
+		if err != nil {
+			t.Fatalf("Got error while marshaling result %v", err)
+		}
+		expected, _ := json.Marshal(map[string]interface{}{"echo": id})
+		if string(data) != string(expected) {
+			t.Fatalf("Result %s did not match with the JSON marshaler output %s", string(data), string(expected))
+		}
+	}
+
+	result := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ echo(id: "wrong") }`})
+	if !result.HasErrors() {
+		t.Fatalf("Was expecting error for invalid ID, but there was no error")
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	id := idx.NewID()
+	values := []interface{}{id, &id, id.String()}
+	for _, val := range values {
+		if serialize(val) != id.String() {
+			t.Fatalf("Serialized value of %v did not match with %s", val, id.String())
+		}
+	}
+	if serialize((*idx.ID)(nil)) != nil || serialize("wrong") != nil || serialize(42) != nil {
+		t.Fatalf("Was expecting nil for invalid values")
+	}
+}
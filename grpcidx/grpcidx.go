@@ -0,0 +1,142 @@
+// Package grpcidx propagates idx.ID values through gRPC metadata (https://pkg.go.dev/google.golang.org/grpc),
+// so a request keeps the same correlation ID across services. Server interceptors read the ID from the
+// incoming metadata into the context, and client interceptors copy it from the context to the outgoing
+// metadata.
+package grpcidx
+
+import (
+	"context"
+	"github.com/ieshan/idx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMetadataKey is the metadata key used unless WithMetadataKey is given.
+const DefaultMetadataKey = "x-request-id"
+
+// Option configures the interceptors.
+type Option func(*config)
+
+type config struct {
+	key        string
+	contextKey *idx.ContextKey
+	generate   bool
+}
+
+// WithMetadataKey sets the metadata key holding the ID. gRPC metadata keys are lower-case.
+func WithMetadataKey(key string) Option {
+	return func(c *config) {
+		c.key = key
+	}
+}
+
+// WithContextKey sets the context key the ID is stored under, idx.RequestIDKey by default.
+func WithContextKey(key *idx.ContextKey) Option {
+	return func(c *config) {
+		c.contextKey = key
+	}
+}
+
+// WithGenerate sets whether a new ID is generated when none is present, which is the default. Without
+// generation, server handlers see no ID and clients send none.
+func WithGenerate(generate bool) Option {
+	return func(c *config) {
+		c.generate = generate
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{key: DefaultMetadataKey, contextKey: idx.RequestIDKey, generate: true}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// serverContext returns ctx carrying the ID of the incoming metadata, or a new one. An invalid ID is
+// rejected with codes.InvalidArgument.
+func (c *config) serverContext(ctx context.Context) (context.Context, error) {
+	var id idx.ID
+	md, _ := metadata.FromIncomingContext(ctx)
+	if vals := md.Get(c.key); len(vals) > 0 {
+		var err error
+		if id, err = idx.FromString(vals[0]); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s metadata %q: %v", c.key, vals[0], err)
+		}
+	} else if c.generate {
+		id = idx.NewID()
+	} else {
+		return ctx, nil
+	}
+	return c.contextKey.NewContext(ctx, id), nil
+}
+
+// clientContext returns ctx with the ID of the context, or a new one, appended to the outgoing metadata,
+// unless the metadata already holds one.
+func (c *config) clientContext(ctx context.Context) context.Context {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(c.key)) > 0 {
+		return ctx
+	}
+	id, ok := c.contextKey.FromContext(ctx)
+	if !ok {
+		if !c.generate {
+			return ctx
+		}
+		id = idx.NewID()
+	}
+	return metadata.AppendToOutgoingContext(ctx, c.key, id.String())
+}
+
+// UnaryServerInterceptor stores the ID of the incoming metadata in the handler context, generating one
+// when it is missing. Requests with an invalid ID fail with codes.InvalidArgument.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := c.serverContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming variant of UnaryServerInterceptor.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	c := newConfig(opts)
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := c.serverContext(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientInterceptor sends the ID of the call context in the outgoing metadata, generating one when
+// the context has none. Metadata already holding the key is left unchanged.
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		return invoker(c.clientContext(ctx), method, req, reply, cc, callOpts...)
+	}
+}
+
+// StreamClientInterceptor is the streaming variant of UnaryClientInterceptor.
+func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	c := newConfig(opts)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(c.clientContext(ctx), desc, cc, method, callOpts...)
+	}
+}
@@ -0,0 +1,114 @@
+package grpcidx
+
+import (
+	"context"
+	"github.com/ieshan/idx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	var got idx.ID
+	var found bool
+	handler := func(ctx context.Context, _ any) (any, error) {
+		got, found = idx.FromContext(ctx)
+		return nil, nil
+	}
+	interceptor := UnaryServerInterceptor()
+
+	id := idx.NewID()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(DefaultMetadataKey, id.String()))
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil || !found || got != id {
+		t.Fatalf("Context ID (%s) did not match with %s: %v", got.String(), id.String(), err)
+	}
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler); err != nil || !found || got == idx.NilID {
+		t.Fatalf("Was expecting a generated ID, got %s: %v", got.String(), err)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(DefaultMetadataKey, "invalid"))
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Was expecting InvalidArgument error, got %v", err)
+	}
+
+	tenantKey := idx.NewContextKey("tenant_id")
+	interceptor = UnaryServerInterceptor(WithGenerate(false), WithMetadataKey("x-tenant-id"), WithContextKey(tenantKey))
+	handler = func(ctx context.Context, _ any) (any, error) {
+		got, found = tenantKey.FromContext(ctx)
+		return nil, nil
+	}
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler); err != nil || found {
+		t.Fatalf("Was expecting no ID without generation, got %s: %v", got.String(), err)
+	}
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant-id", id.String()))
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil || got != id {
+		t.Fatalf("Context ID (%s) did not match with %s: %v", got.String(), id.String(), err)
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	id := idx.NewID()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(DefaultMetadataKey, id.String()))
+	var got idx.ID
+	err := StreamServerInterceptor()(nil, &testServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(_ any, ss grpc.ServerStream) error {
+		got, _ = idx.FromContext(ss.Context())
+		return nil
+	})
+	if err != nil || got != id {
+		t.Fatalf("Context ID (%s) did not match with %s: %v", got.String(), id.String(), err)
+	}
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(DefaultMetadataKey, "invalid"))
+	err = StreamServerInterceptor()(nil, &testServerStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(any, grpc.ServerStream) error {
+		t.Fatalf("Handler should not be called for an invalid ID")
+		return nil
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Was expecting InvalidArgument error, got %v", err)
+	}
+}
+
+func TestClientInterceptors(t *testing.T) {
+	var sent []string
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(DefaultMetadataKey)
+		return nil
+	}
+	interceptor := UnaryClientInterceptor()
+
+	id := idx.NewID()
+	if err := interceptor(idx.NewContext(context.Background(), id), "/svc/Method", nil, nil, nil, invoker); err != nil || len(sent) != 1 || sent[0] != id.String() {
+		t.Fatalf("Sent metadata %v did not match with %s: %v", sent, id.String(), err)
+	}
+	if err := interceptor(context.Background(), "/svc/Method", nil, nil, nil, invoker); err != nil || len(sent) != 1 || !idx.IsValidID(sent[0]) {
+		t.Fatalf("Was expecting a generated ID, got %v: %v", sent, err)
+	}
+	ctx := metadata.AppendToOutgoingContext(idx.NewContext(context.Background(), id), DefaultMetadataKey, "existing")
+	if err := interceptor(ctx, "/svc/Method", nil, nil, nil, invoker); err != nil || len(sent) != 1 || sent[0] != "existing" {
+		t.Fatalf("Was expecting existing metadata to be kept, got %v: %v", sent, err)
+	}
+	if err := UnaryClientInterceptor(WithGenerate(false))(context.Background(), "/svc/Method", nil, nil, nil, invoker); err != nil || len(sent) != 0 {
+		t.Fatalf("Was expecting no metadata without generation, got %v: %v", sent, err)
+	}
+
+	streamer := func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(DefaultMetadataKey)
+		return nil, nil
+	}
+	if _, err := StreamClientInterceptor()(idx.NewContext(context.Background(), id), &grpc.StreamDesc{}, nil, "/svc/Stream", streamer); err != nil || len(sent) != 1 || sent[0] != id.String() {
+		t.Fatalf("Sent metadata %v did not match with %s: %v", sent, id.String(), err)
+	}
+}
@@ -0,0 +1,116 @@
+package idx
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// HealthStats holds the counters collected by a HealthMonitor.
+type HealthStats struct {
+	// Observed is the number of IDs inspected so far.
+	Observed uint64
+	// DuplicateIDs counts IDs that were already present in the window.
+	DuplicateIDs uint64
+	// RepeatedEntropy counts IDs whose entropy matched a different ID in the window,
+	// which typically means the random source was reset or cloned (e.g. after a fork).
+	RepeatedEntropy uint64
+	// LowEntropy counts IDs whose entropy bytes are all identical, as produced by a broken random source.
+	LowEntropy uint64
+}
+
+// HealthMonitor keeps the most recently observed IDs in a ring buffer and counts duplicates and
+// suspicious entropy patterns among them. It is meant for diagnostics and is safe for concurrent use.
+type HealthMonitor struct {
+	mu      sync.Mutex
+	ring    []ID
+	next    int
+	ids     map[ID]int
+	entropy map[[10]byte]int
+	stats   HealthStats
+}
+
+var healthMonitor atomic.Pointer[HealthMonitor]
+
+// NewHealthMonitor returns a HealthMonitor remembering the last size IDs.
+func NewHealthMonitor(size int) *HealthMonitor {
+	if size < 1 {
+		size = 1
+	}
+	return &HealthMonitor{
+		ring:    make([]ID, 0, size),
+		ids:     make(map[ID]int, size),
+		entropy: make(map[[10]byte]int, size),
+	}
+}
+
+// EnableHealthCheck installs a new HealthMonitor of the given window size that observes every ID
+// returned by NewID, and returns it so the caller can read its stats.
+func EnableHealthCheck(size int) *HealthMonitor {
+	m := NewHealthMonitor(size)
+	healthMonitor.Store(m)
+	return m
+}
+
+// DisableHealthCheck stops NewID from reporting to the installed HealthMonitor.
+func DisableHealthCheck() {
+	healthMonitor.Store(nil)
+}
+
+// Observe records id and updates the counters.
+func (m *HealthMonitor) Observe(id ID) {
+	entropy := [10]byte(id[6:])
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats.Observed++
+	if m.ids[id] > 0 {
+		m.stats.DuplicateIDs++
+	} else if m.entropy[entropy] > 0 {
+		m.stats.RepeatedEntropy++
+	}
+	if isLowEntropy(entropy) {
+		m.stats.LowEntropy++
+	}
+
+	if len(m.ring) < cap(m.ring) {
+		m.ring = append(m.ring, id)
+	} else {
+		m.forget(m.ring[m.next])
+		m.ring[m.next] = id
+		m.next = (m.next + 1) % len(m.ring)
+	}
+	m.ids[id]++
+	m.entropy[entropy]++
+}
+
+// Stats returns a snapshot of the counters.
+func (m *HealthMonitor) Stats() HealthStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
+
+// Healthy reports whether no duplicates or suspicious entropy have been observed.
+func (m *HealthMonitor) Healthy() bool {
+	stats := m.Stats()
+	return stats.DuplicateIDs == 0 && stats.RepeatedEntropy == 0 && stats.LowEntropy == 0
+}
+
+func (m *HealthMonitor) forget(id ID) {
+	entropy := [10]byte(id[6:])
+	if m.ids[id]--; m.ids[id] == 0 {
+		delete(m.ids, id)
+	}
+	if m.entropy[entropy]--; m.entropy[entropy] == 0 {
+		delete(m.entropy, entropy)
+	}
+}
+
+func isLowEntropy(entropy [10]byte) bool {
+	for _, b := range entropy[1:] {
+		if b != entropy[0] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package idx
+
+import (
+	"testing"
+)
+
+func TestHealthMonitor(t *testing.T) {
+	m := NewHealthMonitor(4)
+	a, b := NewID(), NewID()
+	m.Observe(a)
+	m.Observe(b)
+	if !m.Healthy() {
+		t.Fatalf("Expecting monitor to be healthy, got %+v", m.Stats())
+	}
+
+	m.Observe(a)
+	repeated := NewID()
+	copy(repeated[6:], b[6:])
+	repeated[0]++
+	m.Observe(repeated)
+	low := NewID()
+	copy(low[6:], make([]byte, 10))
+	m.Observe(low)
+
+	expected := HealthStats{Observed: 5, DuplicateIDs: 1, RepeatedEntropy: 1, LowEntropy: 1}
+	if stats := m.Stats(); stats != expected {
+		t.Fatalf("Stats did not match expectation %+v : %+v", stats, expected)
+	}
+	if m.Healthy() {
+		t.Fatalf("Expecting monitor to be unhealthy")
+	}
+
+	// a was pushed out of the window, so seeing it again is not a duplicate
+	m.Observe(NewID())
+	m.Observe(NewID())
+	m.Observe(a)
+	if stats := m.Stats(); stats.DuplicateIDs != 1 {
+		t.Fatalf("Expecting evicted ID not to count as duplicate, got %+v", stats)
+	}
+}
+
+func TestEnableHealthCheck(t *testing.T) {
+	m := EnableHealthCheck(16)
+	defer DisableHealthCheck()
+	for i := 0; i < 10; i++ {
+		NewID()
+	}
+	if stats := m.Stats(); stats.Observed != 10 || !m.Healthy() {
+		t.Fatalf("Unexpected stats %+v", stats)
+	}
+	DisableHealthCheck()
+	NewID()
+	if stats := m.Stats(); stats.Observed != 10 {
+		t.Fatalf("Expecting disabled monitor not to observe IDs, got %+v", stats)
+	}
+}
@@ -0,0 +1,56 @@
+package idx
+
+import (
+	"encoding/hex"
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	// HexEncodedSize is the length of the hexadecimal form of an ID.
+	HexEncodedSize = 32
+	// dashedHexEncodedSize is the length of the hexadecimal form with dashes in the 8-4-4-4-12 layout.
+	dashedHexEncodedSize = 36
+)
+
+// Hex returns the ID bytes as 32 lower-case hexadecimal characters.
+func (id ID) Hex() string {
+	var dst [HexEncodedSize]byte
+	hex.Encode(dst[:], id[:])
+	return string(dst[:])
+}
+
+// FromHex parses 32 hexadecimal characters in either case, with or without dashes in the 8-4-4-4-12 layout.
+func FromHex(val string) (ID, error) {
+	var id ID
+	switch len(val) {
+	case HexEncodedSize:
+		if _, err := hex.Decode(id[:], []byte(val)); err != nil {
+			return NilID, ulid.ErrInvalidCharacters
+		}
+		return id, nil
+	case dashedHexEncodedSize:
+		return id, decodeDashedHex(val, &id)
+	}
+	return NilID, ulid.ErrDataSize
+}
+
+// decodeDashedHex decodes the 8-4-4-4-12 hexadecimal layout.
+func decodeDashedHex(val string, id *ID) error {
+	if len(val) != dashedHexEncodedSize {
+		return ulid.ErrDataSize
+	}
+	if val[8] != '-' || val[13] != '-' || val[18] != '-' || val[23] != '-' {
+		return ulid.ErrInvalidCharacters
+	}
+	var buf [HexEncodedSize]byte
+	copy(buf[0:8], val[0:8])
+	copy(buf[8:12], val[9:13])
+	copy(buf[12:16], val[14:18])
+	copy(buf[16:20], val[19:23])
+	copy(buf[20:32], val[24:36])
+	if _, err := hex.Decode(id[:], buf[:]); err != nil {
+		*id = NilID
+		return ulid.ErrInvalidCharacters
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestID_Hex(t *testing.T) {
+	id := ID{0x01, 0x8A, 0xA6, 0x89, 0x5E, 0xE7, 0xC8, 0x32, 0xF9, 0xA2, 0x5D, 0x2B, 0x78, 0x33, 0xF3, 0xAB}
+	if id.Hex() != "018aa6895ee7c832f9a25d2b7833f3ab" {
+		t.Fatalf("Unexpected hex value %s", id.Hex())
+	}
+	inputs := []string{
+		"018aa6895ee7c832f9a25d2b7833f3ab",
+		"018AA6895EE7C832F9A25D2B7833F3AB",
+		"018aa689-5ee7-c832-f9a2-5d2b7833f3ab",
+	}
+	for _, val := range inputs {
+		decoded, err := FromHex(val)
+		if err != nil {
+			t.Fatalf("Got error while decoding %s: %v", val, err)
+		}
+		if decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+		}
+	}
+	for i := 0; i < 100; i++ {
+		id = NewID()
+		if decoded, err := FromHex(id.Hex()); err != nil || decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s): %v", id.String(), decoded.String(), err)
+		}
+	}
+}
+
+func TestFromHex(t *testing.T) {
+	invalid := map[string]error{
+		"":                                     ulid.ErrDataSize,
+		"018aa6895ee7c832f9a25d2b7833f3a":      ulid.ErrDataSize,
+		"018aa6895ee7c832f9a25d2b7833f3ag":     ulid.ErrInvalidCharacters,
+		"018aa689-5ee7-c832-f9a2-5d2b7833f3ag": ulid.ErrInvalidCharacters,
+		"018aa6895-ee7-c832-f9a2-5d2b7833f3ab": ulid.ErrInvalidCharacters,
+		strings.Repeat("-", 36):                ulid.ErrInvalidCharacters,
+	}
+	for val, expected := range invalid {
+		if _, err := FromHex(val); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package idx
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// IDs is a slice of IDs implementing sort.Interface, and sql.Scanner and driver.Valuer for PostgreSQL
+// bytea[] and uuid[] columns:
+//
+//	var tags idx.IDs
+//	err := db.QueryRow("SELECT tag_ids FROM orders WHERE id = $1", id).Scan(&tags)
+//
+// Value encodes the array in the format selected with ConfigureValue, so it suits bytea[] columns by
+// default and uuid[] columns with ValueUUID. Since IDs is a driver.Valuer, sqlx.In does not expand it;
+// pass []ID(ids) instead.
+type IDs []ID
+
+func (x IDs) Len() int           { return len(x) }
+func (x IDs) Less(i, j int) bool { return x[i].Compare(x[j]) < 0 }
+func (x IDs) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
+
+// Sort sorts x in increasing order, see SortIDs.
+func (x IDs) Sort() {
+	SortIDs(x)
+}
+
+// Search returns the position of id in the sorted x and whether it is present. When it is not, the
+// position is where id would be inserted.
+func (x IDs) Search(id ID) (int, bool) {
+	return slices.BinarySearchFunc(x, id, ID.Compare)
+}
+
+// Contains reports whether the sorted x holds id.
+func (x IDs) Contains(id ID) bool {
+	_, found := x.Search(id)
+	return found
+}
+
+// Value returns x as a PostgreSQL array literal, or nil for a nil slice. NilID elements are NULL unless
+// WithNilAsZero is configured, like in ID.Value.
+// See https://pkg.go.dev/database/sql/driver#Valuer
+func (x IDs) Value() (driver.Value, error) {
+	if x == nil {
+		return nil, nil
+	}
+	format, nilAsZero := ValueBinary, false
+	if cfg := valueSettings.Load(); cfg != nil {
+		format, nilAsZero = cfg.format, cfg.nilAsZero
+	}
+	b := make([]byte, 0, 2+len(x)*40)
+	b = append(b, '{')
+	for i, id := range x {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		switch {
+		case id == NilID && !nilAsZero:
+			b = append(b, "NULL"...)
+		case format == ValueText:
+			b = id.AppendString(b)
+		case format == ValueUUID:
+			b = append(b, id.UUIDString()...)
+		default:
+			// The backslash of the bytea hex format is escaped within the quoted element.
+			b = append(b, `"\\x`...)
+			b = hex.AppendEncode(b, id[:])
+			b = append(b, '"')
+		}
+	}
+	return string(append(b, '}')), nil
+}
+
+// Scan populates x from a PostgreSQL array literal of bytea, uuid or text elements in any format Scan
+// detects. NULL elements are scanned as NilID, and a NULL array as a nil slice.
+// See https://pkg.go.dev/database/sql#Scanner
+func (x *IDs) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*x = nil
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("idx: cannot scan %T into IDs", src)
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return fmt.Errorf("idx: invalid array literal %q", s)
+	}
+	s = s[1 : len(s)-1]
+	ids := IDs{}
+	for s != "" {
+		var elem string
+		var quoted bool
+		var err error
+		if elem, quoted, s, err = nextArrayElement(s); err != nil {
+			return err
+		}
+		var id ID
+		switch {
+		case !quoted && strings.EqualFold(elem, "NULL"):
+		case strings.HasPrefix(elem, `\x`):
+			if len(elem) != 2+HexEncodedSize {
+				return fmt.Errorf("idx: invalid bytea element %q", elem)
+			}
+			if _, err = hex.Decode(id[:], []byte(elem[2:])); err != nil {
+				return fmt.Errorf("idx: invalid bytea element %q", elem)
+			}
+		default:
+			if id, err = parseAny(elem); err != nil {
+				return err
+			}
+		}
+		ids = append(ids, id)
+	}
+	*x = ids
+	return nil
+}
+
+// nextArrayElement splits the first element off the contents of an array literal, unquoting it.
+func nextArrayElement(s string) (elem string, quoted bool, rest string, err error) {
+	if s[0] != '"' {
+		elem, rest, _ = strings.Cut(s, ",")
+		return strings.TrimSpace(elem), false, rest, nil
+	}
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			if i++; i < len(s) {
+				b.WriteByte(s[i])
+			}
+		case '"':
+			rest = strings.TrimPrefix(s[i+1:], ",")
+			return b.String(), true, rest, nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", false, "", fmt.Errorf("idx: unterminated array element %q", s)
+}
@@ -0,0 +1,91 @@
+package idx
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestIDs_Sort(t *testing.T) {
+	ids := IDs{NewID(), NewID(), NewID(), NewID()}
+	sorted := append(IDs(nil), ids...)
+	ids[0], ids[3] = ids[3], ids[0]
+	ids[1], ids[2] = ids[2], ids[1]
+
+	shuffled := append(IDs(nil), ids...)
+	sort.Sort(shuffled)
+	ids.Sort()
+	for i := range sorted {
+		if ids[i] != sorted[i] || shuffled[i] != sorted[i] {
+			t.Fatalf("Sorted ID (%s) did not match with %s", ids[i].String(), sorted[i].String())
+		}
+	}
+	if i, found := ids.Search(sorted[2]); !found || i != 2 {
+		t.Fatalf("Was expecting ID at 2, got %d %v", i, found)
+	}
+	if i, found := ids.Search(NilID); found || i != 0 {
+		t.Fatalf("Was expecting insertion point 0, got %d %v", i, found)
+	}
+	if !ids.Contains(sorted[3]) || ids.Contains(NewID()) {
+		t.Fatalf("Contains did not match the slice content")
+	}
+}
+
+func TestIDs_Value(t *testing.T) {
+	defer ConfigureValue()
+	ids := IDs{NewID(), NilID, NewID()}
+	expected := map[ValueFormat]string{
+		ValueBinary: `{"\\x` + ids[0].Hex() + `",NULL,"\\x` + ids[2].Hex() + `"}`,
+		ValueUUID:   "{" + ids[0].UUIDString() + ",NULL," + ids[2].UUIDString() + "}",
+		ValueText:   "{" + ids[0].String() + ",NULL," + ids[2].String() + "}",
+	}
+	for format, literal := range expected {
+		ConfigureValue(WithValueFormat(format))
+		value, err := ids.Value()
+		if err != nil || value != literal {
+			t.Fatalf("Array literal %v did not match with %s: %v", value, literal, err)
+		}
+		var result IDs
+		if err = result.Scan(value); err != nil {
+			t.Fatalf("Got error while scanning %v", err)
+		}
+		if len(result) != len(ids) || result[0] != ids[0] || result[1] != NilID || result[2] != ids[2] {
+			t.Fatalf("Scanned IDs %v did not match with %v", result, ids)
+		}
+	}
+	if value, err := IDs(nil).Value(); err != nil || value != nil {
+		t.Fatalf("Was expecting NULL for nil IDs, got %v: %v", value, err)
+	}
+
+	ConfigureValue(WithValueFormat(ValueText), WithNilAsZero())
+	literal := "{" + ids[0].String() + "," + NilID.String() + "," + ids[2].String() + "}"
+	if value, err := ids.Value(); err != nil || value != literal {
+		t.Fatalf("Array literal %v did not match with %s: %v", value, literal, err)
+	}
+	ConfigureValue(WithNilAsZero())
+	literal = `{"\\x` + ids[0].Hex() + `","\\x` + NilID.Hex() + `","\\x` + ids[2].Hex() + `"}`
+	if value, err := ids.Value(); err != nil || value != literal {
+		t.Fatalf("Array literal %v did not match with %s: %v", value, literal, err)
+	}
+}
+
+func TestIDs_Scan(t *testing.T) {
+	id := NewID()
+	var result IDs
+	// PostgreSQL renders bytea[] elements quoted and uuid[] elements bare.
+	for _, src := range []interface{}{`{"\\x` + id.Hex() + `"}`, []byte("{" + id.UUIDString() + "}"), `{"` + id.String() + `"}`} {
+		if err := result.Scan(src); err != nil || len(result) != 1 || result[0] != id {
+			t.Fatalf("Scanned IDs %v did not match with %s: %v", result, id.String(), err)
+		}
+	}
+	if err := result.Scan("{}"); err != nil || result == nil || len(result) != 0 {
+		t.Fatalf("Was expecting empty IDs, got %v: %v", result, err)
+	}
+	if err := result.Scan(nil); err != nil || result != nil {
+		t.Fatalf("Was expecting nil IDs, got %v: %v", result, err)
+	}
+	for _, src := range []interface{}{"[]", `{"\\x0102"}`, `{"unterminated}`, "{invalid}", 42} {
+		if err := result.Scan(src); err == nil {
+			t.Fatalf("Was expecting error for %v", src)
+		}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"github.com/oklog/ulid/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"slices"
+	"time"
 )
 
 type ID [16]byte
@@ -13,15 +16,19 @@ var NilID ID
 var NotNullNilID = ID([16]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
 
 func NewID() ID {
-	return ID(ulid.Make())
+	id := ID(ulid.Make())
+	if m := healthMonitor.Load(); m != nil {
+		m.Observe(id)
+	}
+	return id
 }
 
 func FromString(val string) (ID, error) {
-	ulidVal, err := ulid.ParseStrict(val)
-	if err != nil {
+	var id ID
+	if err := decodeText(val, &id); err != nil {
 		return NilID, err
 	}
-	return ID(ulidVal), nil
+	return id, nil
 }
 
 func IsValidID(val string) bool {
@@ -32,8 +39,88 @@ func IsValidID(val string) bool {
 	return true
 }
 
+// IsValidIDBytes reports whether b holds a strictly valid ULID string. Unlike IsValidID,
+// it neither allocates nor decodes the ID, which makes it suitable for rejecting
+// malformed input on hot paths.
+func IsValidIDBytes(b []byte) bool {
+	if len(b) != ulid.EncodedSize {
+		return false
+	}
+	// The base32 representation encodes 130 bits, so the first character must not exceed '7'
+	if b[0] > '7' {
+		return false
+	}
+	for _, c := range b {
+		if dec[c] == 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// Normalize parses val in any supported format or casing and returns the canonical
+// upper-case ULID string, so that differently formatted inputs map to the same key.
+// Besides ULID strings, the hexadecimal and UUID forms are accepted.
+func Normalize(val string) (string, error) {
+	id, err := parseAny(val)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// parseAny parses val as a ULID string or, based on its length, as the hexadecimal or UUID form.
+func parseAny(val string) (ID, error) {
+	switch len(val) {
+	case HexEncodedSize, dashedHexEncodedSize:
+		return FromHex(val)
+	}
+	return FromString(val)
+}
+
 func (id ID) String() string {
-	return ulid.ULID(id).String()
+	var buf [ulid.EncodedSize]byte
+	_ = ulid.ULID(id).MarshalTextTo(buf[:])
+	return string(buf[:])
+}
+
+// AppendString appends the ULID string of the IDX to dst and returns the extended buffer.
+// It does not allocate when dst has enough spare capacity.
+func (id ID) AppendString(dst []byte) []byte {
+	dst = slices.Grow(dst, ulid.EncodedSize)
+	n := len(dst)
+	dst = dst[:n+ulid.EncodedSize]
+	_ = ulid.ULID(id).MarshalTextTo(dst[n:])
+	return dst
+}
+
+// Time returns the creation time encoded in the ID, in UTC with millisecond precision.
+func (id ID) Time() time.Time {
+	return ulid.Time(ulid.ULID(id).Time()).UTC()
+}
+
+// MinIDAt returns the smallest ID with the millisecond timestamp of t, the lower bound of IDs created at or
+// after t. Times outside the 48-bit timestamp range are clamped.
+func MinIDAt(t time.Time) ID {
+	var id ID
+	putTimestamp(&id, clampTimestamp(t))
+	return id
+}
+
+// MaxIDAt returns the largest ID with the millisecond timestamp of t, the upper bound of IDs created at or
+// before t. Times outside the 48-bit timestamp range are clamped.
+func MaxIDAt(t time.Time) ID {
+	id := ID{6: 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}
+	putTimestamp(&id, clampTimestamp(t))
+	return id
+}
+
+func clampTimestamp(t time.Time) uint64 {
+	ms := t.UnixMilli()
+	if ms < 0 {
+		return 0
+	}
+	return min(uint64(ms), maxTimestamp)
 }
 
 func (id ID) IsZero() bool {
@@ -50,40 +137,87 @@ func (id ID) MarshalText() ([]byte, error) {
 	return ulid.ULID(id).MarshalText()
 }
 
+// AppendText appends the textual representation of the IDX to b. It implements the
+// encoding.TextAppender interface introduced in Go 1.24.
+func (id ID) AppendText(b []byte) ([]byte, error) {
+	return id.AppendString(b), nil
+}
+
 // UnmarshalText populates the byte slice with the ObjectID. Implementing this allows us to use ObjectID
 // as a map key when unmarshalling JSON. See https://pkg.go.dev/encoding#TextUnmarshaler
 func (id *ID) UnmarshalText(b []byte) error {
-	// The ulid UnmarshalText runs in non-strict mode,
-	// therefore doing a strict check of characters to avoid passing un-allowed characters
-	if dec[b[0]] == 0xFF ||
-		dec[b[1]] == 0xFF ||
-		dec[b[2]] == 0xFF ||
-		dec[b[3]] == 0xFF ||
-		dec[b[4]] == 0xFF ||
-		dec[b[5]] == 0xFF ||
-		dec[b[6]] == 0xFF ||
-		dec[b[7]] == 0xFF ||
-		dec[b[8]] == 0xFF ||
-		dec[b[9]] == 0xFF ||
-		dec[b[10]] == 0xFF ||
-		dec[b[11]] == 0xFF ||
-		dec[b[12]] == 0xFF ||
-		dec[b[13]] == 0xFF ||
-		dec[b[14]] == 0xFF ||
-		dec[b[15]] == 0xFF ||
-		dec[b[16]] == 0xFF ||
-		dec[b[17]] == 0xFF ||
-		dec[b[18]] == 0xFF ||
-		dec[b[19]] == 0xFF ||
-		dec[b[20]] == 0xFF ||
-		dec[b[21]] == 0xFF ||
-		dec[b[22]] == 0xFF ||
-		dec[b[23]] == 0xFF ||
-		dec[b[24]] == 0xFF ||
-		dec[b[25]] == 0xFF {
+	return decodeText(b, id)
+}
+
+// decodeText strictly validates and decodes a ULID string in a single pass. Invalid characters map to
+// 0xFF in the dec table, so OR-ing every looked-up value exposes them through the high bits.
+func decodeText[T string | []byte](v T, id *ID) error {
+	if len(v) != ulid.EncodedSize {
+		return ulid.ErrDataSize
+	}
+	var d [ulid.EncodedSize]byte
+	var acc byte
+	for i := 0; i < ulid.EncodedSize; i++ {
+		d[i] = dec[v[i]]
+		acc |= d[i]
+	}
+	if acc&0xE0 != 0 {
 		return ulid.ErrInvalidCharacters
 	}
-	return (*ulid.ULID)(id).UnmarshalText(b)
+	// The base32 representation encodes 130 bits, so the first character must not exceed '7'
+	if d[0] > 7 {
+		return ulid.ErrOverflow
+	}
+
+	// 6 bytes timestamp (48 bits)
+	id[0] = (d[0] << 5) | d[1]
+	id[1] = (d[2] << 3) | (d[3] >> 2)
+	id[2] = (d[3] << 6) | (d[4] << 1) | (d[5] >> 4)
+	id[3] = (d[5] << 4) | (d[6] >> 1)
+	id[4] = (d[6] << 7) | (d[7] << 2) | (d[8] >> 3)
+	id[5] = (d[8] << 5) | d[9]
+
+	// 10 bytes of entropy (80 bits)
+	id[6] = (d[10] << 3) | (d[11] >> 2)
+	id[7] = (d[11] << 6) | (d[12] << 1) | (d[13] >> 4)
+	id[8] = (d[13] << 4) | (d[14] >> 1)
+	id[9] = (d[14] << 7) | (d[15] << 2) | (d[16] >> 3)
+	id[10] = (d[16] << 5) | d[17]
+	id[11] = (d[18] << 3) | (d[19] >> 2)
+	id[12] = (d[19] << 6) | (d[20] << 1) | (d[21] >> 4)
+	id[13] = (d[21] << 4) | (d[22] >> 1)
+	id[14] = (d[22] << 7) | (d[23] << 2) | (d[24] >> 3)
+	id[15] = (d[24] << 5) | d[25]
+	return nil
+}
+
+// MarshalBinary returns the raw 16 bytes of the IDX. See https://pkg.go.dev/encoding#BinaryMarshaler
+func (id ID) MarshalBinary() ([]byte, error) {
+	return ulid.ULID(id).MarshalBinary()
+}
+
+// AppendBinary appends the raw 16 bytes of the IDX to b. It implements the
+// encoding.BinaryAppender interface introduced in Go 1.24.
+func (id ID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, id[:]...), nil
+}
+
+// UnmarshalBinary populates the IDX from its raw 16 bytes. ulid.ErrDataSize is returned for any other length.
+// See https://pkg.go.dev/encoding#BinaryUnmarshaler
+func (id *ID) UnmarshalBinary(b []byte) error {
+	return (*ulid.ULID)(id).UnmarshalBinary(b)
+}
+
+// GobEncode returns the raw 16 bytes of the IDX, so gob streams do not depend on the underlying
+// representation of the type. See https://pkg.go.dev/encoding/gob#GobEncoder
+func (id ID) GobEncode() ([]byte, error) {
+	return id.MarshalBinary()
+}
+
+// GobDecode populates the IDX from the 16 bytes written by GobEncode.
+// See https://pkg.go.dev/encoding/gob#GobDecoder
+func (id *ID) GobDecode(b []byte) error {
+	return id.UnmarshalBinary(b)
 }
 
 // MarshalJSON returns the IDX as a string
@@ -113,18 +247,48 @@ func (id *ID) UnmarshalJSON(b []byte) error {
 	return ulid.ErrDataSize
 }
 
-// Scan implements the sql.Scanner interface. It supports scanning
-// a string or byte slice.
+// Scan implements the sql.Scanner interface. The format is detected from the length of the value, so
+// one type can read columns of mixed schemas: 16-byte slices hold the binary form, 26 characters the
+// ULID string, 32 and 36 characters the hexadecimal and UUID forms. 12-byte slices are treated as
+// legacy ObjectIDs and mapped with FromObjectID. WithScanFormats restricts the accepted formats.
 func (id *ID) Scan(src interface{}) error {
-	// If value is nil, set the ID to NilID
-	if src == nil {
-		copy(id[:], NilID[:])
+	var parsed ID
+	var err error
+	format := ValueBinary
+	switch v := src.(type) {
+	case nil:
+		// If value is nil, set the ID to NilID
+		*id = NilID
+		return nil
+	case string:
+		format = textFormat(len(v))
+		parsed, err = parseAny(v)
+	case []byte:
+		switch len(v) {
+		case len(parsed):
+			parsed = ID(v)
+		case len(primitive.ObjectID{}):
+			parsed = FromObjectID(primitive.ObjectID(v))
+		default:
+			format = textFormat(len(v))
+			parsed, err = parseAny(string(v))
+		}
+	default:
+		return ulid.ErrScanValue
+	}
+	if err != nil {
+		return err
+	}
+	if cfg := valueSettings.Load(); cfg != nil && !cfg.accepts(format) {
+		return ErrScanFormat
 	}
-	return (*ulid.ULID)(id).Scan(src)
+	*id = parsed
+	return nil
 }
 
 // Value implements the sql/driver.Valuer interface, returning the ID as a
-// slice of bytes, by invoking MarshalBinary. If your use case requires a string
+// slice of bytes, by invoking MarshalBinary, and NilID as NULL. ConfigureValue
+// changes both for the whole program. If only some queries require a string
 // representation instead, you can create a wrapper type that calls String()
 // instead.
 //
@@ -155,13 +319,22 @@ func (id *ID) Scan(src interface{}) error {
 //	// Example usage.
 //	db.Exec("...", invalidZeroValuer(id))
 func (id ID) Value() (driver.Value, error) {
+	cfg := valueSettings.Load()
 	// If the ID is NilID, return nil
-	if id == NilID {
+	if id == NilID && (cfg == nil || !cfg.nilAsZero) {
 		return nil, nil
 	}
+	if cfg != nil {
+		return cfg.value(id), nil
+	}
 	return ulid.ULID(id).Value()
 }
 
+// GormDataType declares IDs as binary data to GORM, see the gormidx package for dialect-specific columns.
+func (ID) GormDataType() string {
+	return "bytes"
+}
+
 // Byte to index table for O(1) lookups when unmarshaling.
 // We use 0xFF as sentinel value for invalid indexes.
 var dec = [...]byte{
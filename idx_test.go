@@ -1,7 +1,9 @@
 package idx
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,7 +14,9 @@ import (
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewID(t *testing.T) {
@@ -72,6 +76,189 @@ func TestIsValidID(t *testing.T) {
 	}
 }
 
+func TestIsValidIDBytes(t *testing.T) {
+	id := []byte(NewID().String())
+	if !IsValidIDBytes(id) {
+		t.Fatal("Expecting to be valid, but it's invalid")
+	}
+	if !IsValidIDBytes([]byte("01hak8jpf7s0sfmj2x96w37wxb")) {
+		t.Fatal("Expecting lowercase to be valid, but it's invalid")
+	}
+
+	invalidIds := []string{"", "null", "wrong", "00000", "01HAJ2Q3T69IJMMBDNAMVZ3FQB", "81HAK8JPF7S0SFMJ2X96W37WXB"}
+	for _, val := range invalidIds {
+		if IsValidIDBytes([]byte(val)) {
+			t.Fatalf("Expecting %q to be invalid, but it's valid", val)
+		}
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		IsValidIDBytes(id)
+	})
+	if allocs != 0 {
+		t.Fatalf("Expecting zero allocations, got %v", allocs)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	id := NewID()
+	inputs := []string{id.String(), strings.ToLower(id.String()), id.Hex(), strings.ToUpper(id.Hex()), id.UUIDString()}
+	for _, val := range inputs {
+		normalized, err := Normalize(val)
+		if err != nil {
+			t.Fatalf("Got error while normalizing %s: %v", val, err)
+		}
+		if normalized != id.String() {
+			t.Fatalf("Normalized value (%s) did not match with ID (%s)", normalized, id.String())
+		}
+	}
+	if _, err := Normalize("01HAJ2Q3T69IJMMBDNAMVZ3FQB"); err == nil {
+		t.Fatalf("Was expecting error, but there was no error")
+	}
+}
+
+func TestID_MarshalBinary(t *testing.T) {
+	id := NewID()
+	b, err := id.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Got error while marshaling to binary %v", err)
+	}
+	if len(b) != 16 || ID(b) != id {
+		t.Fatalf("Original ID (%s) did not match with the binary value %v", id.String(), b)
+	}
+	var unm ID
+	if err = unm.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Got error while unmarshaling binary %v", err)
+	}
+	if unm != id {
+		t.Fatalf("Original ID (%s) did not match with unmarshaled ID (%s)", id.String(), unm.String())
+	}
+	for _, invalid := range [][]byte{nil, b[:15], append(b, 0)} {
+		if err = unm.UnmarshalBinary(invalid); !errors.Is(err, ulid.ErrDataSize) {
+			t.Fatalf("Was expecting data size error, got %v", err)
+		}
+	}
+}
+
+func TestID_GobEncode(t *testing.T) {
+	type IdTestStruct struct {
+		ID    ID
+		FkID  *ID
+		Links []ID
+	}
+	id, fk := NewID(), NewID()
+	data := IdTestStruct{ID: id, FkID: &fk, Links: []ID{NewID(), NewID()}}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&data); err != nil {
+		t.Fatalf("Got error while encoding gob %v", err)
+	}
+	var result IdTestStruct
+	if err := gob.NewDecoder(&buf).Decode(&result); err != nil {
+		t.Fatalf("Got error while decoding gob %v", err)
+	}
+	if result.ID != id || result.FkID == nil || *result.FkID != fk || len(result.Links) != 2 || result.Links[1] != data.Links[1] {
+		t.Fatalf("Decoded value %+v did not match with original value %+v", result, data)
+	}
+
+	var unm ID
+	if err := unm.GobDecode(id[:15]); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
+
+func TestID_AppendString(t *testing.T) {
+	id := NewID()
+	if b := id.AppendString(nil); string(b) != id.String() || id.String() != ulid.ULID(id).String() {
+		t.Fatalf("Appended string (%s) did not match with ID (%s)", string(b), id.String())
+	}
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = id.AppendString(buf[:0])
+	})
+	if allocs != 0 {
+		t.Fatalf("Expecting zero allocations, got %v", allocs)
+	}
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = id.String()
+	})
+	if allocs > 1 {
+		t.Fatalf("Expecting at most one allocation, got %v", allocs)
+	}
+}
+
+func TestID_AppendText(t *testing.T) {
+	var _ interface {
+		AppendText([]byte) ([]byte, error)
+		AppendBinary([]byte) ([]byte, error)
+	} = ID{}
+
+	id := NewID()
+	b, err := id.AppendText([]byte("id="))
+	if err != nil {
+		t.Fatalf("Got error while appending text %v", err)
+	}
+	if string(b) != "id="+id.String() {
+		t.Fatalf("Appended text (%s) did not match with ID (%s)", string(b), id.String())
+	}
+	b, err = id.AppendBinary([]byte{0xFF})
+	if err != nil {
+		t.Fatalf("Got error while appending binary %v", err)
+	}
+	if len(b) != 17 || b[0] != 0xFF || ID(b[1:]) != id {
+		t.Fatalf("Appended binary %v did not match with ID (%s)", b, id.String())
+	}
+
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = id.AppendText(buf[:0])
+		buf, _ = id.AppendBinary(buf)
+	})
+	if allocs != 0 {
+		t.Fatalf("Expecting zero allocations, got %v", allocs)
+	}
+}
+
+func TestID_UnmarshalText(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		var unm ID
+		if err := unm.UnmarshalText([]byte(strings.ToLower(id.String()))); err != nil {
+			t.Fatalf("Got error while unmarshaling text %v", err)
+		}
+		expected, _ := ulid.ParseStrict(id.String())
+		if unm != id || unm != ID(expected) {
+			t.Fatalf("Original ID (%s) did not match with unmarshaled ID (%s)", id.String(), unm.String())
+		}
+	}
+	invalid := map[string]error{
+		"":                              ulid.ErrDataSize,
+		"01HAK8JPF7S0SFMJ2X96W37WX":     ulid.ErrDataSize,
+		"01HAK8JPF7S0SFMJ2X96W37WXBB":   ulid.ErrDataSize,
+		"01HAK8JPF7S0SFMJ2X96W37WXI":    ulid.ErrInvalidCharacters,
+		"01HAK8JPF7S0SFMJ2X96W37WX\xff": ulid.ErrInvalidCharacters,
+		"0\x00HAK8JPF7S0SFMJ2X96W37WXB": ulid.ErrInvalidCharacters,
+		"81HAK8JPF7S0SFMJ2X96W37WXB":    ulid.ErrOverflow,
+	}
+	for val, expected := range invalid {
+		var unm ID
+		if err := unm.UnmarshalText([]byte(val)); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+}
+
+func BenchmarkID_UnmarshalText(b *testing.B) {
+	text := []byte(NewID().String())
+	var id ID
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := id.UnmarshalText(text); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestID_MarshalJSON(t *testing.T) {
 	type IdTestStruct struct {
 		Id ID `json:"id"`
@@ -120,6 +307,43 @@ func TestID_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestID_Scan(t *testing.T) {
+	id := NewID()
+	sources := []interface{}{
+		id[:],
+		id.String(),
+		[]byte(id.String()),
+		id.UUIDString(),
+		[]byte(id.UUIDString()),
+		id.Hex(),
+		strings.ToUpper(id.Hex()),
+	}
+	for _, src := range sources {
+		var result ID
+		if err := result.Scan(src); err != nil {
+			t.Fatalf("Got error while scanning %v: %v", src, err)
+		}
+		if result != id {
+			t.Fatalf("Original ID (%s) did not match with the scanned ID %s from %v", id.String(), result.String(), src)
+		}
+	}
+
+	result := id
+	if err := result.Scan(nil); err != nil || result != NilID {
+		t.Fatalf("Was expecting NilID for NULL, got %s: %v", result.String(), err)
+	}
+	result = id
+	if err := result.Scan([]byte{1, 2, 3}); !errors.Is(err, ulid.ErrDataSize) || result != id {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+	if err := result.Scan("01HAK8JPF7S0SFMJ2X96W37WXI"); !errors.Is(err, ulid.ErrInvalidCharacters) || result != id {
+		t.Fatalf("Was expecting invalid characters error, got %v", err)
+	}
+	if err := result.Scan(42); !errors.Is(err, ulid.ErrScanValue) {
+		t.Fatalf("Was expecting scan value error, got %v", err)
+	}
+}
+
 func TestIdForMongo(t *testing.T) {
 	type IdTestStruct struct {
 		ID    ID     `bson:"_id"`
@@ -335,3 +559,24 @@ func TestIdForPostgres(t *testing.T) {
 		t.Fatalf("Record found even though it should be deleted")
 	}
 }
+
+func TestMinIDAt(t *testing.T) {
+	id := NewID()
+	ts := id.Time()
+	min, max := MinIDAt(ts), MaxIDAt(ts)
+	if min.Compare(id) > 0 || max.Compare(id) < 0 {
+		t.Fatalf("ID %s is not within %s and %s", id.String(), min.String(), max.String())
+	}
+	if !min.Time().Equal(ts) || !max.Time().Equal(ts) {
+		t.Fatalf("Bounds %s and %s do not have timestamp %v", min.String(), max.String(), ts)
+	}
+	if max.String()[10:] != "ZZZZZZZZZZZZZZZZ" || min.String()[10:] != "0000000000000000" {
+		t.Fatalf("Unexpected bounds %s and %s", min.String(), max.String())
+	}
+	if MinIDAt(time.Unix(-1, 0)) != NilID {
+		t.Fatalf("Time before the epoch was not clamped: %s", MinIDAt(time.Unix(-1, 0)).String())
+	}
+	if MaxIDAt(time.Unix(1<<40, 0)).String() != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Fatalf("Time after the maximum was not clamped: %s", MaxIDAt(time.Unix(1<<40, 0)).String())
+	}
+}
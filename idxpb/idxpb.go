@@ -0,0 +1,63 @@
+// Package idxpb holds the idx.v1.ID protobuf message generated from proto/idx/v1/id.proto, together with
+// helpers converting between idx.ID and protobuf fields. Throughout the package, idx.NilID maps to an
+// empty value and back.
+package idxpb
+
+//go:generate protoc --proto_path=../proto --go_out=.. --go_opt=module=github.com/ieshan/idx idx/v1/id.proto
+
+import (
+	"github.com/ieshan/idx"
+)
+
+// ToProto returns id as an idx.v1.ID message.
+func ToProto(id idx.ID) *ID {
+	return &ID{Value: ToBytes(id)}
+}
+
+// FromProto returns the ID carried by msg. A nil message or an empty value decodes as idx.NilID,
+// any value that is not 16 bytes long is rejected with ulid.ErrDataSize.
+func FromProto(msg *ID) (idx.ID, error) {
+	if msg == nil {
+		return idx.NilID, nil
+	}
+	return FromBytes(msg.Value)
+}
+
+// Validate reports whether the message holds a well-formed ID.
+func (x *ID) Validate() error {
+	_, err := FromProto(x)
+	return err
+}
+
+// ToBytes returns id for a plain bytes field.
+func ToBytes(id idx.ID) []byte {
+	if id.IsZero() {
+		return nil
+	}
+	return id[:]
+}
+
+// FromBytes decodes a plain bytes field holding the raw 16 bytes of an ID.
+func FromBytes(b []byte) (idx.ID, error) {
+	var id idx.ID
+	if len(b) == 0 {
+		return id, nil
+	}
+	return id, id.UnmarshalBinary(b)
+}
+
+// ToString returns id for a plain string field, as its ULID string.
+func ToString(id idx.ID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.String()
+}
+
+// FromString decodes a plain string field holding a ULID string.
+func FromString(s string) (idx.ID, error) {
+	if s == "" {
+		return idx.NilID, nil
+	}
+	return idx.FromString(s)
+}
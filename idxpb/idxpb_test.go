@@ -0,0 +1,78 @@
+package idxpb
+
+import (
+	"errors"
+	"github.com/ieshan/idx"
+	"github.com/oklog/ulid/v2"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestToProto(t *testing.T) {
+	id := idx.NewID()
+	b, err := proto.Marshal(ToProto(id))
+	if err != nil {
+		t.Fatalf("Got error while marshaling message %v", err)
+	}
+	var msg ID
+	if err = proto.Unmarshal(b, &msg); err != nil {
+		t.Fatalf("Got error while unmarshaling message %v", err)
+	}
+	if err = msg.Validate(); err != nil {
+		t.Fatalf("Got error while validating message %v", err)
+	}
+	result, err := FromProto(&msg)
+	if err != nil {
+		t.Fatalf("Got error while converting message %v", err)
+	}
+	if result != id {
+		t.Fatalf("Original ID (%s) did not match with converted ID (%s)", id.String(), result.String())
+	}
+
+	if len(ToProto(idx.NilID).Value) != 0 {
+		t.Fatalf("NilID should be converted to an empty value")
+	}
+	for _, msg := range []*ID{nil, {}} {
+		if result, err = FromProto(msg); err != nil || result != idx.NilID {
+			t.Fatalf("Was expecting NilID without error, got %s %v", result.String(), err)
+		}
+	}
+	invalid := &ID{Value: id[:15]}
+	if _, err = FromProto(invalid); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+	if err = invalid.Validate(); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	id := idx.NewID()
+	result, err := FromBytes(ToBytes(id))
+	if err != nil || result != id {
+		t.Fatalf("Original ID (%s) did not match with converted ID (%s): %v", id.String(), result.String(), err)
+	}
+	if ToBytes(idx.NilID) != nil {
+		t.Fatalf("NilID should be converted to nil bytes")
+	}
+	if _, err = FromBytes(id[:4]); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
+
+func TestFromString(t *testing.T) {
+	id := idx.NewID()
+	result, err := FromString(ToString(id))
+	if err != nil || result != id {
+		t.Fatalf("Original ID (%s) did not match with converted ID (%s): %v", id.String(), result.String(), err)
+	}
+	if ToString(idx.NilID) != "" {
+		t.Fatalf("NilID should be converted to an empty string")
+	}
+	if result, err = FromString(""); err != nil || result != idx.NilID {
+		t.Fatalf("Was expecting NilID without error, got %s %v", result.String(), err)
+	}
+	if _, err = FromString("wrong"); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package idx
+
+import (
+	"sync"
+)
+
+// Interner caches recent string to ID parses and ID to string renders, for consumers receiving the same
+// small set of IDs millions of times. Rendered strings are shared, so repeated calls to String do not
+// allocate, and ParseBytes looks up the cache without converting its argument.
+//
+// The cache keeps two generations of up to size entries each: when the current one is full it becomes
+// the previous one and the older entries are dropped, and entries found in the previous generation are
+// moved back to the current one. An Interner is safe for concurrent use.
+type Interner struct {
+	size int
+
+	mu              sync.Mutex
+	parsed, oldIDs  map[string]ID
+	rendered, oldSs map[ID]string
+}
+
+// NewInterner returns an Interner keeping at least the size most recently used IDs in each direction.
+// A size of zero or less disables caching.
+func NewInterner(size int) *Interner {
+	return &Interner{
+		size:     size,
+		parsed:   make(map[string]ID),
+		rendered: make(map[ID]string),
+	}
+}
+
+// Parse is FromString cached by the Interner. Invalid values are not cached.
+func (in *Interner) Parse(val string) (ID, error) {
+	in.mu.Lock()
+	id, ok := in.lookupParsed(val)
+	in.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+	id, err := FromString(val)
+	if err != nil {
+		return NilID, err
+	}
+	in.mu.Lock()
+	in.storeParsed(val, id)
+	in.mu.Unlock()
+	return id, nil
+}
+
+// ParseBytes is Parse for a byte slice. It only allocates the cached string when the value is missing.
+func (in *Interner) ParseBytes(val []byte) (ID, error) {
+	in.mu.Lock()
+	id, ok := in.parsed[string(val)]
+	if !ok {
+		if id, ok = in.oldIDs[string(val)]; ok {
+			delete(in.oldIDs, string(val))
+			in.storeParsed(string(val), id)
+		}
+	}
+	in.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+	return in.Parse(string(val))
+}
+
+// String is ID.String cached by the Interner.
+func (in *Interner) String(id ID) string {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	if s, ok := in.rendered[id]; ok {
+		return s
+	}
+	s, ok := in.oldSs[id]
+	if ok {
+		delete(in.oldSs, id)
+	} else {
+		s = id.String()
+	}
+	if in.size > 0 {
+		if len(in.rendered) >= in.size {
+			in.oldSs, in.rendered = in.rendered, make(map[ID]string, in.size)
+		}
+		in.rendered[id] = s
+	}
+	return s
+}
+
+// Len returns the number of strings and IDs currently cached.
+func (in *Interner) Len() (parsed, rendered int) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return len(in.parsed) + len(in.oldIDs), len(in.rendered) + len(in.oldSs)
+}
+
+func (in *Interner) lookupParsed(val string) (ID, bool) {
+	if id, ok := in.parsed[val]; ok {
+		return id, true
+	}
+	id, ok := in.oldIDs[val]
+	if ok {
+		delete(in.oldIDs, val)
+		in.storeParsed(val, id)
+	}
+	return id, ok
+}
+
+func (in *Interner) storeParsed(val string, id ID) {
+	if in.size <= 0 {
+		return
+	}
+	if len(in.parsed) >= in.size {
+		in.oldIDs, in.parsed = in.parsed, make(map[string]ID, in.size)
+	}
+	in.parsed[val] = id
+}
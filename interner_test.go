@@ -0,0 +1,78 @@
+package idx
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestInterner(t *testing.T) {
+	in := NewInterner(2)
+	ids := randomIDs(3)
+	for _, id := range ids {
+		parsed, err := in.Parse(id.String())
+		if err != nil || parsed != id {
+			t.Fatalf("Parsed ID (%s) did not match with %s: %v", parsed.String(), id.String(), err)
+		}
+		if parsed, err = in.ParseBytes([]byte(id.String())); err != nil || parsed != id {
+			t.Fatalf("Parsed ID (%s) did not match with %s: %v", parsed.String(), id.String(), err)
+		}
+		if s := in.String(id); s != id.String() {
+			t.Fatalf("Rendered string %s did not match with %s", s, id.String())
+		}
+	}
+	if parsed, rendered := in.Len(); parsed != 3 || rendered != 3 {
+		t.Fatalf("Was expecting 3 cached entries, got %d and %d", parsed, rendered)
+	}
+	// Filling the current generation again drops the oldest one.
+	in.String(NewID())
+	in.String(NewID())
+	if _, ok := in.oldSs[ids[0]]; ok {
+		t.Fatalf("Oldest generation was not dropped")
+	}
+	if _, rendered := in.Len(); rendered != 3 {
+		t.Fatalf("Was expecting 3 cached strings, got %d", rendered)
+	}
+	if _, err := in.Parse("invalid"); err == nil {
+		t.Fatalf("Was expecting error for invalid ID")
+	}
+	if _, err := in.ParseBytes([]byte("invalid")); err == nil {
+		t.Fatalf("Was expecting error for invalid ID")
+	}
+
+	disabled := NewInterner(0)
+	if id, err := disabled.Parse(ids[0].String()); err != nil || id != ids[0] || disabled.String(id) != id.String() {
+		t.Fatalf("Disabled interner did not parse %s: %v", ids[0].String(), err)
+	}
+	if parsed, rendered := disabled.Len(); parsed != 0 || rendered != 0 {
+		t.Fatalf("Disabled interner cached %d and %d entries", parsed, rendered)
+	}
+}
+
+func TestInterner_Concurrent(t *testing.T) {
+	in := NewInterner(16)
+	ids := randomIDs(64)
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				id := ids[i%len(ids)]
+				if parsed, err := in.Parse(in.String(id)); err != nil || parsed != id {
+					t.Errorf("Parsed ID (%s) did not match with %s: %v", parsed.String(), id.String(), err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkInterner_ParseBytes(b *testing.B) {
+	in := NewInterner(1024)
+	val := []byte(NewID().String())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = in.ParseBytes(val)
+	}
+}
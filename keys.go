@@ -0,0 +1,135 @@
+package idx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"time"
+)
+
+// MemcacheMaxKeyLength is the longest key memcached accepts.
+const MemcacheMaxKeyLength = 250
+
+// KeySeparator separates the prefix from the ID in the keys built by Key, following the Redis convention.
+const KeySeparator = ":"
+
+// Key returns the cache key "<prefix>:<ulid>", e.g. user:01HAK8JPF7S0SFMJ2X96W37WXB, or the bare ULID
+// string when prefix is empty. IDs implement encoding.BinaryMarshaler and BinaryUnmarshaler, so go-redis
+// stores them as their 16 bytes when used as values:
+//
+//	err := rdb.Set(ctx, idx.Key("session", sessionID), userID, time.Hour).Err()
+//	err = rdb.Get(ctx, idx.Key("session", sessionID)).Scan(&userID)
+func Key(prefix string, id ID) string {
+	if prefix == "" {
+		return id.String()
+	}
+	return prefix + KeySeparator + id.String()
+}
+
+// ParseKey returns the ID of a key built by Key with the same prefix. ErrPrefix is returned when the key
+// has another prefix.
+func ParseKey(prefix, key string) (ID, error) {
+	if prefix != "" {
+		var ok bool
+		if key, ok = strings.CutPrefix(key, prefix+KeySeparator); !ok {
+			return NilID, ErrPrefix
+		}
+	}
+	return FromString(key)
+}
+
+// MemcacheKey returns a key valid for memcached whatever the prefix: it is built like Key, with control
+// characters, whitespace, bytes outside ASCII and '%' in the prefix escaped as %XX, and prefixes that would
+// make the key longer than MemcacheMaxKeyLength shortened and suffixed with a hash of the full prefix, so
+// distinct prefixes keep distinct keys. ParseMemcacheKey returns the ID back.
+func MemcacheKey(prefix string, id ID) string {
+	if prefix == "" {
+		return id.String()
+	}
+	escaped := make([]byte, 0, len(prefix))
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		if c <= ' ' || c >= 0x7f || c == '%' {
+			escaped = append(escaped, '%', "0123456789ABCDEF"[c>>4], "0123456789ABCDEF"[c&0x0f])
+			continue
+		}
+		escaped = append(escaped, c)
+	}
+	if limit := MemcacheMaxKeyLength - len(KeySeparator) - ulid.EncodedSize; len(escaped) > limit {
+		sum := sha256.Sum256(escaped)
+		escaped = append(escaped[:limit-1-hex.EncodedLen(16)], '~')
+		escaped = hex.AppendEncode(escaped, sum[:16])
+	}
+	return string(escaped) + KeySeparator + id.String()
+}
+
+// ParseMemcacheKey returns the ID of a key built by MemcacheKey, whatever its prefix.
+func ParseMemcacheKey(key string) (ID, error) {
+	if len(key) > ulid.EncodedSize {
+		if !strings.HasSuffix(key[:len(key)-ulid.EncodedSize], KeySeparator) {
+			return NilID, ErrPrefix
+		}
+		key = key[len(key)-ulid.EncodedSize:]
+	}
+	return FromString(key)
+}
+
+// BinaryKey returns prefix followed by the 16 ID bytes, a key that keeps IDs ordered by creation time
+// in byte-ordered stores such as bbolt and Badger. Prefixes such as a tenant ID compose by appending:
+//
+//	key := idx.BinaryKey(idx.BinaryKey([]byte("orders/"), tenantID), orderID)
+//
+// The returned slice never shares memory with prefix.
+func BinaryKey(prefix []byte, id ID) []byte {
+	key := make([]byte, 0, len(prefix)+len(id))
+	return append(append(key, prefix...), id[:]...)
+}
+
+// ParseBinaryKey returns the ID of a key built by BinaryKey with the same prefix, e.g. in iteration
+// callbacks. ErrPrefix is returned when the key has another prefix.
+func ParseBinaryKey(prefix, key []byte) (ID, error) {
+	rest, ok := bytes.CutPrefix(key, prefix)
+	if !ok {
+		return NilID, ErrPrefix
+	}
+	var id ID
+	return id, id.UnmarshalBinary(rest)
+}
+
+// SplitBinaryKey splits a key built by BinaryKey into its prefix and ID, for keys whose prefix is not
+// known in advance. ulid.ErrDataSize is returned for keys shorter than an ID.
+func SplitBinaryKey(key []byte) ([]byte, ID, error) {
+	if len(key) < len(ID{}) {
+		return nil, NilID, ulid.ErrDataSize
+	}
+	n := len(key) - len(ID{})
+	return key[:n], ID(key[n:]), nil
+}
+
+// KeyRange returns the [lower, upper) bounds of the keys built by BinaryKey with prefix for IDs generated
+// in [from, to), for time-sliced scans in LSM stores such as Pebble, goleveldb and RocksDB:
+//
+//	lower, upper := idx.KeyRange(prefix, from, to)
+//	iter, err := db.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+//
+// Bounds have millisecond precision.
+func KeyRange(prefix []byte, from, to time.Time) ([]byte, []byte) {
+	return BinaryKey(prefix, MinIDAt(from)), BinaryKey(prefix, MinIDAt(to))
+}
+
+// PrefixRange returns the [lower, upper) bounds of all keys starting with prefix, which can also end
+// with the leading bytes of an ID. upper is nil, meaning unbounded, when prefix is empty or only holds
+// 0xFF bytes.
+func PrefixRange(prefix []byte) ([]byte, []byte) {
+	lower := bytes.Clone(prefix)
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xFF {
+			upper := bytes.Clone(prefix[:i+1])
+			upper[i]++
+			return lower, upper
+		}
+	}
+	return lower, nil
+}
@@ -0,0 +1,172 @@
+package idx
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"go.etcd.io/bbolt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKey(t *testing.T) {
+	id := NewID()
+	key := Key("user", id)
+	if key != "user:"+id.String() {
+		t.Fatalf("Unexpected key %q", key)
+	}
+	parsed, err := ParseKey("user", key)
+	if err != nil || parsed != id {
+		t.Fatalf("Original ID (%s) did not match with the ID from key %s: %v", id.String(), parsed.String(), err)
+	}
+	if parsed, err = ParseKey("", Key("", id)); err != nil || parsed != id {
+		t.Fatalf("Original ID (%s) did not match with the ID from key %s: %v", id.String(), parsed.String(), err)
+	}
+	if _, err = ParseKey("session", key); !errors.Is(err, ErrPrefix) {
+		t.Fatalf("Was expecting prefix error, got %v", err)
+	}
+	if _, err = ParseKey("user", "user:"); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+
+	var _ encoding.BinaryMarshaler = id
+	var _ encoding.BinaryUnmarshaler = &parsed
+}
+
+func TestMemcacheKey(t *testing.T) {
+	id := NewID()
+	prefixes := []string{"", "user", "tenant 42\n%", "caf\u00e9", strings.Repeat("p", 300), strings.Repeat("p", 299) + "q"}
+	keys := map[string]bool{}
+	for _, prefix := range prefixes {
+		key := MemcacheKey(prefix, id)
+		if len(key) > MemcacheMaxKeyLength {
+			t.Fatalf("Key for prefix %q is %d bytes long", prefix, len(key))
+		}
+		for i := 0; i < len(key); i++ {
+			if key[i] <= ' ' || key[i] >= 0x7f {
+				t.Fatalf("Key %q contains the invalid byte %#x", key, key[i])
+			}
+		}
+		if keys[key] {
+			t.Fatalf("Key %q was generated twice", key)
+		}
+		keys[key] = true
+		parsed, err := ParseMemcacheKey(key)
+		if err != nil || parsed != id {
+			t.Fatalf("Original ID (%s) did not match with the ID from key %s: %v", id.String(), parsed.String(), err)
+		}
+	}
+	if key := MemcacheKey("user", id); key != Key("user", id) {
+		t.Fatalf("Key %q did not match with %q", key, Key("user", id))
+	}
+	if key := MemcacheKey("a b%", id); key != "a%20b%25:"+id.String() {
+		t.Fatalf("Unexpected key %q", key)
+	}
+	if _, err := ParseMemcacheKey("user-" + id.String()); !errors.Is(err, ErrPrefix) {
+		t.Fatalf("Was expecting prefix error, got %v", err)
+	}
+}
+
+func TestBinaryKey(t *testing.T) {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "keys.db"), 0o600, nil)
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	defer db.Close()
+
+	tenant, other := NewID(), NewID()
+	prefix := BinaryKey([]byte("orders/"), tenant)
+	ids := make([]ID, 5)
+	err = db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucket([]byte("data"))
+		if err != nil {
+			return err
+		}
+		// Insert in reverse order, the store sorts them back.
+		for i := len(ids) - 1; i >= 0; i-- {
+			ids[i] = MinIDAt(time.Now().Add(time.Duration(i) * time.Second))
+			if err = b.Put(BinaryKey(prefix, ids[i]), nil); err != nil {
+				return err
+			}
+		}
+		return b.Put(BinaryKey(BinaryKey([]byte("orders/"), other), NewID()), nil)
+	})
+	if err != nil {
+		t.Fatalf("Got error while writing keys %v", err)
+	}
+
+	var scanned []ID
+	err = db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket([]byte("data")).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			id, err := ParseBinaryKey(prefix, k)
+			if err != nil {
+				return err
+			}
+			scanned = append(scanned, id)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Got error while reading keys %v", err)
+	}
+	if !slices.Equal(scanned, ids) {
+		t.Fatalf("Scanned IDs %v did not match with %v", scanned, ids)
+	}
+
+	key := BinaryKey(prefix, ids[0])
+	p, id, err := SplitBinaryKey(key)
+	if err != nil || !bytes.Equal(p, prefix) || id != ids[0] {
+		t.Fatalf("Unexpected split %x %s: %v", p, id.String(), err)
+	}
+	if _, err = ParseBinaryKey([]byte("users/"), key); !errors.Is(err, ErrPrefix) {
+		t.Fatalf("Was expecting prefix error, got %v", err)
+	}
+	if _, err = ParseBinaryKey(prefix, prefix); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+	if _, _, err = SplitBinaryKey([]byte("short")); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
+
+func TestKeyRange(t *testing.T) {
+	prefix := []byte("events/")
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	lower, upper := KeyRange(prefix, from, to)
+	inRange := func(key []byte) bool {
+		return bytes.Compare(key, lower) >= 0 && bytes.Compare(key, upper) < 0
+	}
+	if !inRange(BinaryKey(prefix, MinIDAt(from))) || !inRange(BinaryKey(prefix, MaxIDAt(to.Add(-time.Millisecond)))) {
+		t.Fatalf("Keys inside the window fell outside [%x, %x)", lower, upper)
+	}
+	if inRange(BinaryKey(prefix, MinIDAt(to))) || inRange(BinaryKey(prefix, MaxIDAt(from.Add(-time.Millisecond)))) {
+		t.Fatalf("Keys outside the window fell inside [%x, %x)", lower, upper)
+	}
+	if inRange(BinaryKey([]byte("events0"), MinIDAt(from))) {
+		t.Fatalf("Key with another prefix fell inside [%x, %x)", lower, upper)
+	}
+}
+
+func TestPrefixRange(t *testing.T) {
+	id := NewID()
+	lower, upper := PrefixRange(BinaryKey([]byte("events/"), id)[:13])
+	if !bytes.Equal(lower, []byte("events/"+string(id[:6]))) {
+		t.Fatalf("Unexpected lower bound %x", lower)
+	}
+	key := BinaryKey([]byte("events/"), id)
+	if bytes.Compare(key, lower) < 0 || bytes.Compare(key, upper) >= 0 {
+		t.Fatalf("Key %x fell outside [%x, %x)", key, lower, upper)
+	}
+	if lower, upper = PrefixRange([]byte{'a', 0xFF, 0xFF}); !bytes.Equal(upper, []byte{'b'}) || len(lower) != 3 {
+		t.Fatalf("Unexpected bounds [%x, %x)", lower, upper)
+	}
+	if _, upper = PrefixRange([]byte{0xFF}); upper != nil {
+		t.Fatalf("Was expecting no upper bound, got %x", upper)
+	}
+}
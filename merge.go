@@ -0,0 +1,180 @@
+package idx
+
+import (
+	"errors"
+	"io"
+	"iter"
+)
+
+// ErrUnsortedStream is returned by the stream set operations when a stream is not in ascending order.
+var ErrUnsortedStream = errors.New("idx: id stream is not sorted")
+
+// MergeSorted merges streams of IDs in ascending order, such as per-shard exports, into one ascending
+// stream. IDs present several times, within a stream or across streams, are yielded once. Each stream is
+// consumed lazily, one ID ahead, so the streams are never materialized. The result is only ordered if
+// every stream is.
+func MergeSorted(streams ...iter.Seq[ID]) iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		h := make([]mergeCursor, 0, len(streams))
+		for _, stream := range streams {
+			next, stop := iter.Pull(stream)
+			defer stop()
+			if id, ok := next(); ok {
+				h = append(h, mergeCursor{id: id, next: next})
+			}
+		}
+		for i := len(h)/2 - 1; i >= 0; i-- {
+			siftDownCursors(h, i)
+		}
+		var last ID
+		started := false
+		for len(h) > 0 {
+			if id := h[0].id; !started || id != last {
+				if !yield(id) {
+					return
+				}
+				last, started = id, true
+			}
+			if id, ok := h[0].next(); ok {
+				h[0].id = id
+			} else {
+				h[0] = h[len(h)-1]
+				h = h[:len(h)-1]
+			}
+			siftDownCursors(h, 0)
+		}
+	}
+}
+
+// mergeCursor holds the next ID of a stream being merged.
+type mergeCursor struct {
+	id   ID
+	next func() (ID, bool)
+}
+
+func siftDownCursors(h []mergeCursor, i int) {
+	for {
+		smallest, l, r := i, 2*i+1, 2*i+2
+		if l < len(h) && h[l].id.Compare(h[smallest].id) < 0 {
+			smallest = l
+		}
+		if r < len(h) && h[r].id.Compare(h[smallest].id) < 0 {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		h[i], h[smallest] = h[smallest], h[i]
+		i = smallest
+	}
+}
+
+// IntersectSorted returns the IDs present in both ascending streams a and b, once each, in ascending
+// order. Both streams are consumed lazily.
+func IntersectSorted(a, b iter.Seq[ID]) iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+		x, okA := nextA()
+		y, okB := nextB()
+		var last ID
+		started := false
+		for okA && okB {
+			switch c := x.Compare(y); {
+			case c < 0:
+				x, okA = nextA()
+			case c > 0:
+				y, okB = nextB()
+			default:
+				if !started || x != last {
+					if !yield(x) {
+						return
+					}
+					last, started = x, true
+				}
+				x, okA = nextA()
+				y, okB = nextB()
+			}
+		}
+	}
+}
+
+// DifferenceSorted returns the IDs of the ascending stream a missing from the ascending stream b, once
+// each, in ascending order. Both streams are consumed lazily.
+func DifferenceSorted(a, b iter.Seq[ID]) iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+		y, okB := nextB()
+		var last ID
+		started := false
+		for x, okA := nextA(); okA; x, okA = nextA() {
+			for okB && y.Compare(x) < 0 {
+				y, okB = nextB()
+			}
+			if (okB && y == x) || (started && x == last) {
+				continue
+			}
+			if !yield(x) {
+				return
+			}
+			last, started = x, true
+		}
+	}
+}
+
+// UnionStreams calls fn with every ID present in a or b, once each and in ascending order. a and b are
+// binary streams written by WriteIDs from ascending IDs, such as multi-GB exports; they are read one ID
+// at a time and never held in memory. Read errors, and ErrUnsortedStream if a stream is out of order, end
+// that stream and are returned once the operation completes. An error returned by fn stops the operation
+// and is returned as is.
+func UnionStreams(a, b io.Reader, fn func(ID) error) error {
+	return streamSetOperation(a, b, fn, func(a, b iter.Seq[ID]) iter.Seq[ID] {
+		return MergeSorted(a, b)
+	})
+}
+
+// IntersectStreams calls fn with every ID present in both a and b. See UnionStreams.
+func IntersectStreams(a, b io.Reader, fn func(ID) error) error {
+	return streamSetOperation(a, b, fn, IntersectSorted)
+}
+
+// DifferenceStreams calls fn with every ID present in a but not in b. See UnionStreams.
+func DifferenceStreams(a, b io.Reader, fn func(ID) error) error {
+	return streamSetOperation(a, b, fn, DifferenceSorted)
+}
+
+func streamSetOperation(a, b io.Reader, fn func(ID) error, op func(a, b iter.Seq[ID]) iter.Seq[ID]) error {
+	ra, rb := NewIDReader(a), NewIDReader(b)
+	for id := range op(ascendingIDs(ra), ascendingIDs(rb)) {
+		if err := fn(id); err != nil {
+			return err
+		}
+	}
+	if err := ra.Err(); err != nil {
+		return err
+	}
+	return rb.Err()
+}
+
+// ascendingIDs iterates over the IDs of r, stopping it with ErrUnsortedStream on the first ID smaller than
+// the previous one.
+func ascendingIDs(r *IDReader) iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		var last ID
+		for id := range r.All() {
+			if id.Compare(last) < 0 {
+				r.err = ErrUnsortedStream
+				return
+			}
+			last = id
+			if !yield(id) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package idx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestMergeSorted(t *testing.T) {
+	ids := randomIDs(100)
+	SortIDs(ids)
+	var shards [3][]ID
+	for i, id := range ids {
+		shards[i%3] = append(shards[i%3], id)
+	}
+	// Duplicates within and across streams are yielded once.
+	shards[0] = append(shards[0], ids[99])
+	shards[1] = append([]ID{ids[0]}, shards[1]...)
+
+	merged := slices.Collect(MergeSorted(slices.Values(shards[0]), slices.Values(shards[1]), slices.Values(shards[2]), slices.Values([]ID(nil))))
+	if !slices.Equal(merged, ids) {
+		t.Fatalf("Merged IDs %v did not match with %v", merged, ids)
+	}
+	if merged = slices.Collect(MergeSorted()); len(merged) != 0 {
+		t.Fatalf("Was expecting no IDs, got %v", merged)
+	}
+
+	n := 0
+	for range MergeSorted(slices.Values(shards[0]), slices.Values(shards[1])) {
+		if n++; n == 5 {
+			break
+		}
+	}
+	if n != 5 {
+		t.Fatalf("Was expecting to stop after 5 IDs, got %d", n)
+	}
+}
+
+func TestIntersectSorted(t *testing.T) {
+	ids := randomIDs(10)
+	SortIDs(ids)
+	a := []ID{ids[0], ids[1], ids[1], ids[3], ids[5], ids[8]}
+	b := []ID{ids[1], ids[2], ids[3], ids[3], ids[8], ids[9]}
+	if result := slices.Collect(IntersectSorted(slices.Values(a), slices.Values(b))); !slices.Equal(result, []ID{ids[1], ids[3], ids[8]}) {
+		t.Fatalf("Unexpected intersection %v", result)
+	}
+	if result := slices.Collect(DifferenceSorted(slices.Values(a), slices.Values(b))); !slices.Equal(result, []ID{ids[0], ids[5]}) {
+		t.Fatalf("Unexpected difference %v", result)
+	}
+	if result := slices.Collect(DifferenceSorted(slices.Values(b), slices.Values([]ID(nil)))); !slices.Equal(result, []ID{ids[1], ids[2], ids[3], ids[8], ids[9]}) {
+		t.Fatalf("Unexpected difference %v", result)
+	}
+}
+
+func TestStreams(t *testing.T) {
+	ids := randomIDs(1000)
+	SortIDs(ids)
+	var a, b bytes.Buffer
+	_ = WriteIDs(&a, ids[:600])
+	_ = WriteIDs(&b, ids[400:])
+
+	expected := map[string][]ID{"union": ids, "intersect": ids[400:600], "difference": ids[:400]}
+	operations := map[string]func(a, b io.Reader, fn func(ID) error) error{
+		"union":      UnionStreams,
+		"intersect":  IntersectStreams,
+		"difference": DifferenceStreams,
+	}
+	for name, operation := range operations {
+		var result []ID
+		err := operation(bytes.NewReader(a.Bytes()), bytes.NewReader(b.Bytes()), func(id ID) error {
+			result = append(result, id)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Got error while computing %s %v", name, err)
+		}
+		if !slices.Equal(result, expected[name]) {
+			t.Fatalf("Result of %s has %d IDs, was expecting %d", name, len(result), len(expected[name]))
+		}
+	}
+
+	stop := errors.New("stop")
+	err := UnionStreams(bytes.NewReader(a.Bytes()), bytes.NewReader(b.Bytes()), func(ID) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Fatalf("Was expecting callback error, got %v", err)
+	}
+
+	var unsorted bytes.Buffer
+	_ = WriteIDs(&unsorted, []ID{ids[1], ids[0]})
+	err = IntersectStreams(bytes.NewReader(a.Bytes()), &unsorted, func(ID) error { return nil })
+	if !errors.Is(err, ErrUnsortedStream) {
+		t.Fatalf("Was expecting ErrUnsortedStream, got %v", err)
+	}
+	err = DifferenceStreams(bytes.NewReader(a.Bytes()), bytes.NewReader([]byte("XYZ")), func(ID) error { return nil })
+	if !errors.Is(err, ErrStreamHeader) {
+		t.Fatalf("Was expecting ErrStreamHeader, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package idx
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var tStringID = reflect.TypeOf(StringID{})
+
+// AssignMongoID fills a missing or zero _id of doc with NewID, the way the driver fills missing _id
+// fields with ObjectIDs, and returns the document to insert. Pointers to structs with an ID, *ID or
+// StringID field tagged `bson:"_id"` and bson.M documents are updated in place; bson.D documents and
+// struct values are copied when the _id has to be added. Other documents are returned unchanged.
+func AssignMongoID(doc interface{}) interface{} {
+	switch d := doc.(type) {
+	case bson.M:
+		if isZeroMongoID(d["_id"]) {
+			d["_id"] = NewID()
+		}
+		return d
+	case bson.D:
+		for i, e := range d {
+			if e.Key == "_id" {
+				if isZeroMongoID(e.Value) {
+					d[i].Value = NewID()
+				}
+				return d
+			}
+		}
+		return append(bson.D{{Key: "_id", Value: NewID()}}, d...)
+	}
+	rv := reflect.ValueOf(doc)
+	switch {
+	case rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct:
+		assignMongoStructID(rv.Elem())
+	case rv.Kind() == reflect.Struct:
+		copied := reflect.New(rv.Type())
+		copied.Elem().Set(rv)
+		if assignMongoStructID(copied.Elem()) {
+			return copied.Interface()
+		}
+	}
+	return doc
+}
+
+// MongoIDRange returns a filter selecting documents whose _id was generated in [from, to), using the
+// timestamp embedded in the IDs instead of an extra indexed field. Bounds have millisecond precision.
+//
+//	cursor, err := coll.Find(ctx, idx.MongoIDRange(start, start.Add(24*time.Hour)))
+//
+// The bounds are IDs, so they are stored in the representation configured with RegisterBSONCodec.
+func MongoIDRange(from, to time.Time) bson.D {
+	return bson.D{{Key: "_id", Value: bson.D{{Key: "$gte", Value: MinIDAt(from)}, {Key: "$lt", Value: MinIDAt(to)}}}}
+}
+
+// assignMongoStructID sets the _id field of the struct rv when it is zero and reports whether it did.
+func assignMongoStructID(rv reflect.Value) bool {
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		if name, _, _ := strings.Cut(field.Tag.Get("bson"), ","); name != "_id" {
+			continue
+		}
+		fv := rv.Field(i)
+		if !fv.CanSet() || !fv.IsZero() {
+			return false
+		}
+		switch field.Type {
+		case tID:
+			fv.Set(reflect.ValueOf(NewID()))
+		case tIDPtr:
+			id := NewID()
+			fv.Set(reflect.ValueOf(&id))
+		case tStringID:
+			fv.Set(reflect.ValueOf(StringID(NewID())))
+		default:
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// isZeroMongoID reports whether v is a missing or zero ID.
+func isZeroMongoID(v interface{}) bool {
+	switch id := v.(type) {
+	case nil:
+		return true
+	case ID:
+		return id == NilID
+	case *ID:
+		return id == nil || *id == NilID
+	case StringID:
+		return ID(id) == NilID
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package idx
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"testing"
+	"time"
+)
+
+func TestAssignMongoID(t *testing.T) {
+	type IdTestStruct struct {
+		ID    ID     `bson:"_id,omitempty"`
+		Value string `bson:"value"`
+	}
+	type PtrTestStruct struct {
+		ID *ID `bson:"_id"`
+	}
+	doc := IdTestStruct{Value: "a"}
+	if AssignMongoID(&doc) != &doc || doc.ID == NilID {
+		t.Fatalf("Was expecting an ID to be assigned, got %+v", doc)
+	}
+	existing := doc.ID
+	AssignMongoID(&doc)
+	if doc.ID != existing {
+		t.Fatalf("Original ID (%s) did not match with %s", existing.String(), doc.ID.String())
+	}
+	if copied, ok := AssignMongoID(IdTestStruct{}).(*IdTestStruct); !ok || copied.ID == NilID {
+		t.Fatalf("Was expecting a copy with an ID, got %+v", copied)
+	}
+	var ptr PtrTestStruct
+	if AssignMongoID(&ptr); ptr.ID == nil || *ptr.ID == NilID {
+		t.Fatalf("Was expecting an ID to be assigned, got %+v", ptr)
+	}
+
+	m := bson.M{"value": "a"}
+	if AssignMongoID(m); m["_id"] == nil || m["_id"].(ID) == NilID {
+		t.Fatalf("Was expecting an ID to be assigned, got %v", m)
+	}
+	d, ok := AssignMongoID(bson.D{{Key: "value", Value: "a"}}).(bson.D)
+	if !ok || len(d) != 2 || d[0].Key != "_id" || d[0].Value.(ID) == NilID {
+		t.Fatalf("Was expecting an ID to be assigned, got %v", d)
+	}
+	d = bson.D{{Key: "_id", Value: NilID}}
+	if AssignMongoID(d); d[0].Value.(ID) == NilID {
+		t.Fatalf("Was expecting an ID to be assigned, got %v", d)
+	}
+	d = bson.D{{Key: "_id", Value: existing}}
+	if AssignMongoID(d); d[0].Value.(ID) != existing {
+		t.Fatalf("Original ID (%s) did not match with %v", existing.String(), d[0].Value)
+	}
+	if AssignMongoID("raw") != "raw" {
+		t.Fatalf("Was expecting unsupported documents to be returned unchanged")
+	}
+}
+
+func TestMongoIDRange(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	expected := bson.D{{Key: "_id", Value: bson.D{{Key: "$gte", Value: MinIDAt(from)}, {Key: "$lt", Value: MinIDAt(to)}}}}
+	filter := MongoIDRange(from, to)
+	b, err := bson.Marshal(filter)
+	if err != nil {
+		t.Fatalf("Got error while marshaling filter %v", err)
+	}
+	e, _ := bson.Marshal(expected)
+	if string(b) != string(e) {
+		t.Fatalf("Filter %v did not match with %v", filter, expected)
+	}
+	bounds := filter[0].Value.(bson.D)
+	inside, outside := MinIDAt(from.Add(time.Hour)), MinIDAt(to)
+	if inside.Compare(bounds[0].Value.(ID)) < 0 || inside.Compare(bounds[1].Value.(ID)) >= 0 || outside.Compare(bounds[1].Value.(ID)) < 0 {
+		t.Fatalf("Bounds %v do not select [from, to)", bounds)
+	}
+}
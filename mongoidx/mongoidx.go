@@ -0,0 +1,150 @@
+// Package mongoidx stores idx.ID keys in MongoDB collections with the official driver
+// (https://pkg.go.dev/go.mongodb.org/mongo-driver/mongo). The BSON encoding of IDs is provided by the
+// idx package itself, see idx.RegisterBSONCodec.
+package mongoidx
+
+import (
+	"context"
+	"fmt"
+	"github.com/ieshan/idx"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InsertOne inserts doc into coll after filling its _id with idx.AssignMongoID, so forgetting to set the
+// ID does not insert the all-zero key.
+func InsertOne(ctx context.Context, coll *mongo.Collection, doc interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	return coll.InsertOne(ctx, idx.AssignMongoID(doc), opts...)
+}
+
+// InsertMany inserts docs into coll after filling their _id with idx.AssignMongoID.
+func InsertMany(ctx context.Context, coll *mongo.Collection, docs []interface{}, opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	assigned := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		assigned[i] = idx.AssignMongoID(doc)
+	}
+	return coll.InsertMany(ctx, assigned, opts...)
+}
+
+// CreatedAtBackfill is the idx.CreatedAtBackfill of collections: it sets a missing or null timestamp
+// field from the time embedded in the _id of each document, batch by batch and in _id order.
+//
+//	backfill := mongoidx.CreatedAtBackfill{Field: "created_at"}
+//	err := backfill.Run(ctx, client.Database("shop").Collection("orders"))
+//
+// The _id may be stored in any representation idx.ID decodes, such as the generic binary written for [16]byte
+// fields or ULID strings, and documents are updated through their stored _id. Documents that already have
+// the field are skipped, which makes the backfill safe to restart.
+type CreatedAtBackfill struct {
+	// Field is the timestamp field to fill.
+	Field string
+	// BatchSize is the number of documents updated per bulk write, 1000 when not set.
+	BatchSize int
+	// After skips the documents whose _id is not greater than the given value, such as the Last value
+	// reported by Progress. MongoDB only compares values of the same BSON type, so documents whose _id has
+	// another type than After are skipped as well.
+	After interface{}
+	// Progress is called after every bulk write, with the stored _id of the last document as a
+	// bson.RawValue.
+	Progress func(idx.ConversionProgress)
+}
+
+// Run backfills the documents, returning at the first _id that is not an ID or at the first error.
+func (b CreatedAtBackfill) Run(ctx context.Context, coll *mongo.Collection) error {
+	size := b.BatchSize
+	if size <= 0 {
+		size = 1000
+	}
+	// Updated documents no longer match the filter, so every batch reads the next ones without a cursor on
+	// _id, which would only compare values of the type of the last one.
+	filter := bson.D{{Key: b.Field, Value: nil}}
+	if b.After != nil {
+		filter = append(filter, bson.E{Key: "_id", Value: bson.D{{Key: "$gt", Value: b.After}}})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(size)).SetProjection(bson.D{{Key: "_id", Value: 1}})
+	progress := idx.ConversionProgress{}
+	for {
+		cursor, err := coll.Find(ctx, filter, opts)
+		if err != nil {
+			return err
+		}
+		var docs []struct {
+			ID bson.RawValue `bson:"_id"`
+		}
+		if err = cursor.All(ctx, &docs); err != nil {
+			return err
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+		models := make([]mongo.WriteModel, len(docs))
+		for i, doc := range docs {
+			var id idx.ID
+			if err = doc.ID.Unmarshal(&id); err != nil {
+				return fmt.Errorf("mongoidx: backfilling _id %s: %w", doc.ID, err)
+			}
+			// Filtering on the stored value keeps matching _ids written in another representation.
+			models[i] = mongo.NewUpdateOneModel().
+				SetFilter(bson.D{{Key: "_id", Value: doc.ID}}).
+				SetUpdate(bson.D{{Key: "$set", Value: bson.D{{Key: b.Field, Value: id.Time()}}}})
+		}
+		result, err := coll.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return fmt.Errorf("mongoidx: backfilling _id %s: no document matched", docs[0].ID)
+		}
+		progress.Converted += result.MatchedCount
+		progress.Last = docs[len(docs)-1].ID
+		if b.Progress != nil {
+			b.Progress(progress)
+		}
+		if len(docs) < size {
+			return nil
+		}
+	}
+}
+
+// AuditCollection is the idx.AuditTable of collections, checking field, which is usually _id. Findings are
+// keyed by the _id of the documents.
+func AuditCollection(ctx context.Context, coll *mongo.Collection, field string, report func(idx.AuditFinding) error) (int64, error) {
+	cursor, err := coll.Find(ctx, bson.D{}, options.Find().SetProjection(bson.D{{Key: field, Value: 1}}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+	a := idx.NewAuditor()
+	for cursor.Next(ctx) {
+		key := cursor.Current.Lookup("_id")
+		raw, err := cursor.Current.LookupErr(field)
+		var value interface{}
+		if err == nil {
+			value = auditValue(raw)
+		}
+		if finding, ok := a.Check(key.String(), value); ok {
+			if err = report(finding); err != nil {
+				return a.Scanned, err
+			}
+		}
+	}
+	return a.Scanned, cursor.Err()
+}
+
+// auditValue returns a BSON value in a form idx.ID.Scan understands.
+func auditValue(raw bson.RawValue) interface{} {
+	if s, ok := raw.StringValueOK(); ok {
+		return s
+	}
+	if _, data, ok := raw.BinaryOK(); ok {
+		return data
+	}
+	if oid, ok := raw.ObjectIDOK(); ok {
+		return oid[:]
+	}
+	if raw.Type == bson.TypeNull {
+		return nil
+	}
+	return raw.String()
+}
@@ -0,0 +1,38 @@
+package idx
+
+import (
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	msgpackNil  = 0xc0
+	msgpackBin8 = 0xc4
+	msgpackStr8 = 0xd9
+	// msgpackFixStr26 is the fixstr header of a 26 byte string, as written for the ULID text form.
+	msgpackFixStr26 = 0xa0 | ulid.EncodedSize
+)
+
+// MarshalMsgpack encodes the IDX as a 16-byte MessagePack bin value. It implements the msgpack.Marshaler
+// interface of github.com/vmihailenco/msgpack without depending on it.
+func (id ID) MarshalMsgpack() ([]byte, error) {
+	b := make([]byte, 2, 2+len(id))
+	b[0], b[1] = msgpackBin8, byte(len(id))
+	return append(b, id[:]...), nil
+}
+
+// UnmarshalMsgpack decodes a MessagePack bin value produced by MarshalMsgpack. ULID strings written by the
+// text marshaling fallback are accepted as well, and nil decodes as NilID.
+func (id *ID) UnmarshalMsgpack(b []byte) error {
+	switch {
+	case len(b) == 1 && b[0] == msgpackNil:
+		*id = NilID
+		return nil
+	case len(b) == 2+len(id) && b[0] == msgpackBin8 && b[1] == byte(len(id)):
+		return id.UnmarshalBinary(b[2:])
+	case len(b) == 1+ulid.EncodedSize && b[0] == msgpackFixStr26:
+		return id.UnmarshalText(b[1:])
+	case len(b) == 2+ulid.EncodedSize && b[0] == msgpackStr8 && b[1] == ulid.EncodedSize:
+		return id.UnmarshalText(b[2:])
+	}
+	return ulid.ErrDataSize
+}
@@ -0,0 +1,67 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"testing"
+)
+
+func TestID_MarshalMsgpack(t *testing.T) {
+	type IdTestStruct struct {
+		ID    ID   `msgpack:"id"`
+		FkID  *ID  `msgpack:"fk_id"`
+		Links []ID `msgpack:"links"`
+	}
+	id, fk := NewID(), NewID()
+	data := IdTestStruct{ID: id, FkID: &fk, Links: []ID{NewID()}}
+	b, err := msgpack.Marshal(&data)
+	if err != nil {
+		t.Fatalf("Got error while marshaling to msgpack %v", err)
+	}
+	var result IdTestStruct
+	if err = msgpack.Unmarshal(b, &result); err != nil {
+		t.Fatalf("Got error while unmarshaling msgpack %v", err)
+	}
+	if result.ID != id || result.FkID == nil || *result.FkID != fk || len(result.Links) != 1 || result.Links[0] != data.Links[0] {
+		t.Fatalf("Decoded value %+v did not match with original value %+v", result, data)
+	}
+
+	single, err := msgpack.Marshal(id)
+	if err != nil {
+		t.Fatalf("Got error while marshaling to msgpack %v", err)
+	}
+	if len(single) != 18 || single[0] != 0xc4 || single[1] != 16 || ID(single[2:]) != id {
+		t.Fatalf("ID was not encoded as 16-byte bin value: %v", single)
+	}
+}
+
+func TestID_UnmarshalMsgpack(t *testing.T) {
+	id := NewID()
+	str, err := msgpack.Marshal(id.String())
+	if err != nil {
+		t.Fatalf("Got error while marshaling to msgpack %v", err)
+	}
+	nilVal, err := msgpack.Marshal(nil)
+	if err != nil {
+		t.Fatalf("Got error while marshaling to msgpack %v", err)
+	}
+	var unm ID
+	if err = msgpack.Unmarshal(str, &unm); err != nil {
+		t.Fatalf("Got error while unmarshaling msgpack string %v", err)
+	}
+	if unm != id {
+		t.Fatalf("Original ID (%s) did not match with the ID from msgpack %s", id.String(), unm.String())
+	}
+	if err = unm.UnmarshalMsgpack(nilVal); err != nil || unm != NilID {
+		t.Fatalf("Was expecting NilID without error, got %s %v", unm.String(), err)
+	}
+
+	invalid, err := msgpack.Marshal(id[:15])
+	if err != nil {
+		t.Fatalf("Got error while marshaling to msgpack %v", err)
+	}
+	if err = unm.UnmarshalMsgpack(invalid); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package idx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// ErrNullID is returned by scanners wrapped with Strict when the column is NULL.
+var ErrNullID = errors.New("idx: NULL scanned into ID")
+
+// NullID is an ID that may be NULL, in the style of the database/sql Null types.
+type NullID struct {
+	ID    ID
+	Valid bool
+}
+
+// NewNullID returns a valid NullID holding id.
+func NewNullID(id ID) NullID {
+	return NullID{ID: id, Valid: true}
+}
+
+// Value returns nil when the NullID is not valid and the ID otherwise, including for NilID, in the
+// representation selected with ConfigureValue.
+// See https://pkg.go.dev/database/sql/driver#Valuer
+func (n NullID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if cfg := valueSettings.Load(); cfg != nil {
+		return cfg.value(n.ID), nil
+	}
+	return n.ID[:], nil
+}
+
+// Scan populates the NullID, which is valid unless src is nil. See https://pkg.go.dev/database/sql#Scanner
+func (n *NullID) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullID{}
+		return nil
+	}
+	if err := n.ID.Scan(src); err != nil {
+		*n = NullID{}
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalJSON returns null when the NullID is not valid and the ULID string otherwise.
+func (n NullID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.ID.MarshalJSON()
+}
+
+// UnmarshalJSON populates the NullID, which is valid unless the value is null.
+func (n *NullID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*n = NullID{}
+		return nil
+	}
+	var id ID
+	if err := id.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	*n = NewNullID(id)
+	return nil
+}
+
+// Strict wraps dst, which is usually a *ID or a pointer to one of the wrapper types, so that NULL is
+// rejected with ErrNullID instead of being scanned as NilID:
+//
+//	err := row.Scan(idx.Strict(&id), idx.Strict(&uuid))
+//
+// Scanning is lenient by default: ID, UUID, SwappedID and SQLServerID all map NULL to NilID, and NullID
+// reports it through Valid.
+func Strict(dst sql.Scanner) sql.Scanner {
+	return strictScanner{dst}
+}
+
+type strictScanner struct {
+	dst sql.Scanner
+}
+
+func (s strictScanner) Scan(src interface{}) error {
+	if src == nil {
+		return ErrNullID
+	}
+	return s.dst.Scan(src)
+}
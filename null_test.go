@@ -0,0 +1,70 @@
+package idx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNullID_Scan(t *testing.T) {
+	id := NewID()
+	value, err := NewNullID(id).Value()
+	if err != nil || !bytes.Equal(value.([]byte), id[:]) {
+		t.Fatalf("Value %v did not match with %s: %v", value, id.String(), err)
+	}
+	if value, err = NewNullID(NilID).Value(); err != nil || !bytes.Equal(value.([]byte), NilID[:]) {
+		t.Fatalf("Was expecting zero bytes for valid NilID, got %v: %v", value, err)
+	}
+	if value, err = (NullID{}).Value(); err != nil || value != nil {
+		t.Fatalf("Was expecting nil value for invalid NullID, got %v: %v", value, err)
+	}
+
+	var n NullID
+	if err = n.Scan(id[:]); err != nil || !n.Valid || n.ID != id {
+		t.Fatalf("Scanned NullID %+v did not match with %s: %v", n, id.String(), err)
+	}
+	if err = n.Scan(nil); err != nil || n.Valid {
+		t.Fatalf("Was expecting invalid NullID for NULL, got %+v: %v", n, err)
+	}
+	if err = n.Scan([]byte{1}); err == nil || n.Valid {
+		t.Fatalf("Was expecting error and invalid NullID, got %+v: %v", n, err)
+	}
+}
+
+func TestNullID_MarshalJSON(t *testing.T) {
+	type IdTestStruct struct {
+		ID    NullID `json:"id"`
+		Empty NullID `json:"empty"`
+	}
+	data := IdTestStruct{ID: NewNullID(NewID())}
+	b, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("Got error while marshaling %v", err)
+	}
+	if string(b) != `{"id":"`+data.ID.ID.String()+`","empty":null}` {
+		t.Fatalf("Unexpected JSON %s", b)
+	}
+	var result IdTestStruct
+	if err = json.Unmarshal(b, &result); err != nil || result != data {
+		t.Fatalf("Unmarshaled value %+v did not match with %+v: %v", result, data, err)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	id := NewID()
+	var result ID
+	if err := Strict(&result).Scan(id[:]); err != nil || result != id {
+		t.Fatalf("Original ID (%s) did not match with the scanned ID %s: %v", id.String(), result.String(), err)
+	}
+	if err := Strict(&result).Scan(nil); !errors.Is(err, ErrNullID) || result != id {
+		t.Fatalf("Was expecting NULL ID error, got %v", err)
+	}
+	var uuid UUID
+	if err := Strict(&uuid).Scan(nil); !errors.Is(err, ErrNullID) {
+		t.Fatalf("Was expecting NULL ID error, got %v", err)
+	}
+	if err := result.Scan(nil); err != nil || result != NilID {
+		t.Fatalf("Was expecting NilID for lenient scan, got %s: %v", result.String(), err)
+	}
+}
@@ -0,0 +1,41 @@
+package idx
+
+import (
+	"encoding/binary"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrNotObjectID is returned by ToObjectID when the ID was not produced by FromObjectID.
+var ErrNotObjectID = errors.New("idx: ID does not hold an ObjectID")
+
+// FromObjectID maps a 12-byte MongoDB ObjectID into an ID deterministically, so existing collections can be
+// migrated in place. The layout is:
+//
+//	bytes 0-5:   ObjectID timestamp (seconds) converted to milliseconds, as the 48-bit ULID timestamp
+//	bytes 6-13:  the remaining 8 ObjectID bytes (process unique value and counter)
+//	bytes 14-15: zero padding
+//
+// The resulting IDs sort by creation time like the original ObjectIDs, and ToObjectID reverses the mapping.
+func FromObjectID(oid primitive.ObjectID) ID {
+	var id ID
+	ms := uint64(binary.BigEndian.Uint32(oid[:4])) * 1000
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+	copy(id[6:14], oid[4:])
+	return id
+}
+
+// ToObjectID reverses FromObjectID. ErrNotObjectID is returned when the ID does not follow the
+// FromObjectID layout.
+func ToObjectID(id ID) (primitive.ObjectID, error) {
+	var oid primitive.ObjectID
+	ms := uint64(id[0])<<40 | uint64(id[1])<<32 | uint64(binary.BigEndian.Uint32(id[2:6]))
+	if ms%1000 != 0 || ms/1000 > 0xFFFFFFFF || id[14] != 0 || id[15] != 0 {
+		return oid, ErrNotObjectID
+	}
+	binary.BigEndian.PutUint32(oid[:4], uint32(ms/1000))
+	copy(oid[4:], id[6:14])
+	return oid, nil
+}
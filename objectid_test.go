@@ -0,0 +1,72 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"testing"
+	"time"
+)
+
+func TestFromObjectID(t *testing.T) {
+	oid := primitive.NewObjectID()
+	id := FromObjectID(oid)
+	if ulidTime := int64(ulid.ULID(id).Time()); ulidTime != oid.Timestamp().UnixMilli() {
+		t.Fatalf("ID timestamp %d did not match with ObjectID timestamp %v", ulidTime, oid.Timestamp())
+	}
+	back, err := ToObjectID(id)
+	if err != nil {
+		t.Fatalf("Got error while converting back to ObjectID %v", err)
+	}
+	if back != oid {
+		t.Fatalf("Original ObjectID (%s) did not match with converted ObjectID (%s)", oid.Hex(), back.Hex())
+	}
+
+	older := primitive.NewObjectIDFromTimestamp(time.Now().Add(-time.Hour))
+	if FromObjectID(older).Compare(id) != -1 {
+		t.Fatalf("Converted IDs should keep the ObjectID ordering")
+	}
+	if _, err = ToObjectID(NewID()); !errors.Is(err, ErrNotObjectID) {
+		t.Fatalf("Was expecting not ObjectID error, got %v", err)
+	}
+}
+
+func TestID_ScanObjectID(t *testing.T) {
+	oid := primitive.NewObjectID()
+	var id ID
+	if err := id.Scan(oid[:]); err != nil {
+		t.Fatalf("Got error while scanning ObjectID %v", err)
+	}
+	if id != FromObjectID(oid) {
+		t.Fatalf("Scanned ID (%s) did not match with converted ObjectID", id.String())
+	}
+}
+
+func TestID_UnmarshalBSONObjectID(t *testing.T) {
+	type IdTestStruct struct {
+		ID ID `bson:"_id"`
+	}
+	oid := primitive.NewObjectID()
+	raw, err := bson.Marshal(bson.M{"_id": oid})
+	if err != nil {
+		t.Fatalf("Got error while marshaling to BSON %v", err)
+	}
+	var result IdTestStruct
+	if err = bson.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("Got error while unmarshaling BSON %v", err)
+	}
+	if result.ID != FromObjectID(oid) {
+		t.Fatalf("Decoded ID (%s) did not match with converted ObjectID", result.ID.String())
+	}
+
+	reg := bson.NewRegistry()
+	RegisterBSONCodec(reg)
+	result = IdTestStruct{}
+	if err = bson.UnmarshalWithRegistry(reg, raw, &result); err != nil {
+		t.Fatalf("Got error while unmarshaling BSON with registry %v", err)
+	}
+	if result.ID != FromObjectID(oid) {
+		t.Fatalf("Decoded ID (%s) did not match with converted ObjectID", result.ID.String())
+	}
+}
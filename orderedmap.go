@@ -0,0 +1,172 @@
+package idx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// OrderedMap maps IDs to values of type V and iterates them in insertion order, or in ID order after
+// SortByID, e.g. to return API responses keyed by ID in a stable order. JSON marshaling writes an object
+// keyed by the ID strings in that order, and unmarshaling keeps the order of the document.
+//
+// Lookups are constant time. Delete is linear as it keeps the remaining entries in order. The zero value
+// is an empty map ready to use. An OrderedMap is not safe for concurrent modification.
+type OrderedMap[V any] struct {
+	index  map[ID]int
+	keys   []ID
+	values []V
+}
+
+// NewOrderedMap returns an empty map with room for size entries.
+func NewOrderedMap[V any](size int) *OrderedMap[V] {
+	return &OrderedMap[V]{index: make(map[ID]int, size), keys: make([]ID, 0, size), values: make([]V, 0, size)}
+}
+
+// Len returns the number of entries in the map.
+func (m *OrderedMap[V]) Len() int {
+	return len(m.keys)
+}
+
+// Get returns the value stored for id and whether it was present.
+func (m *OrderedMap[V]) Get(id ID) (V, bool) {
+	if i, ok := m.index[id]; ok {
+		return m.values[i], true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has reports whether id is present in the map.
+func (m *OrderedMap[V]) Has(id ID) bool {
+	_, ok := m.index[id]
+	return ok
+}
+
+// Set stores value for id. A new ID is appended at the end, an existing one keeps its position.
+func (m *OrderedMap[V]) Set(id ID, value V) {
+	if i, ok := m.index[id]; ok {
+		m.values[i] = value
+		return
+	}
+	if m.index == nil {
+		m.index = make(map[ID]int)
+	}
+	m.index[id] = len(m.keys)
+	m.keys = append(m.keys, id)
+	m.values = append(m.values, value)
+}
+
+// Delete removes id from the map and reports whether it was present.
+func (m *OrderedMap[V]) Delete(id ID) bool {
+	i, ok := m.index[id]
+	if !ok {
+		return false
+	}
+	delete(m.index, id)
+	m.keys = slices.Delete(m.keys, i, i+1)
+	m.values = slices.Delete(m.values, i, i+1)
+	for j := i; j < len(m.keys); j++ {
+		m.index[m.keys[j]] = j
+	}
+	return true
+}
+
+// SortByID reorders the entries by increasing ID, hence by creation time.
+func (m *OrderedMap[V]) SortByID() {
+	order := make([]int, len(m.keys))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return m.keys[a].Compare(m.keys[b])
+	})
+	keys, values := make([]ID, len(order)), make([]V, len(order))
+	for i, j := range order {
+		keys[i], values[i] = m.keys[j], m.values[j]
+		m.index[keys[i]] = i
+	}
+	m.keys, m.values = keys, values
+}
+
+// Keys returns the IDs of the map in order. The returned slice must not be modified.
+func (m *OrderedMap[V]) Keys() []ID {
+	return m.keys
+}
+
+// All returns an iterator over the entries of the map in order.
+func (m *OrderedMap[V]) All() iter.Seq2[ID, V] {
+	return func(yield func(ID, V) bool) {
+		for i, id := range m.keys {
+			if !yield(id, m.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the map in order.
+func (m *OrderedMap[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON returns a JSON object keyed by the ID strings, in the order of the map.
+func (m *OrderedMap[V]) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 2+len(m.keys)*32)
+	buf = append(buf, '{')
+	for i, id := range m.keys {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, '"')
+		buf = id.AppendString(buf)
+		buf = append(buf, '"', ':')
+		value, err := json.Marshal(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, value...)
+	}
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON replaces the entries of the map with the members of a JSON object keyed by ID strings,
+// keeping their order. null leaves the map empty.
+func (m *OrderedMap[V]) UnmarshalJSON(b []byte) error {
+	*m = OrderedMap[V]{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("idx: cannot unmarshal %v into OrderedMap", tok)
+	}
+	for dec.More() {
+		if tok, err = dec.Token(); err != nil {
+			return err
+		}
+		var id ID
+		if err = id.UnmarshalText([]byte(tok.(string))); err != nil {
+			return err
+		}
+		var value V
+		if err = dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Set(id, value)
+	}
+	_, err = dec.Token()
+	return err
+}
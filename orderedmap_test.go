@@ -0,0 +1,83 @@
+package idx
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	ids := randomIDs(5)
+	var m OrderedMap[int]
+	for i, id := range ids {
+		m.Set(id, i)
+	}
+	m.Set(ids[0], 10)
+	if m.Len() != 5 || !slices.Equal(m.Keys(), ids) {
+		t.Fatalf("Keys %v did not match with insertion order %v", m.Keys(), ids)
+	}
+	if v, ok := m.Get(ids[0]); !ok || v != 10 {
+		t.Fatalf("Was expecting 10, got %d", v)
+	}
+	if !m.Delete(ids[1]) || m.Delete(ids[1]) || m.Has(ids[1]) {
+		t.Fatalf("Delete did not remove the ID")
+	}
+	if v, ok := m.Get(ids[4]); !ok || v != 4 {
+		t.Fatalf("Was expecting 4 after delete, got %d", v)
+	}
+
+	var values []int
+	for _, v := range m.All() {
+		values = append(values, v)
+	}
+	if !slices.Equal(values, []int{10, 2, 3, 4}) || !slices.Equal(slices.Collect(m.Values()), values) {
+		t.Fatalf("Unexpected values %v", values)
+	}
+
+	m.SortByID()
+	if !slices.IsSortedFunc(m.Keys(), ID.Compare) {
+		t.Fatalf("Keys are not sorted after SortByID")
+	}
+	for i, id := range ids {
+		want := i
+		if i == 0 {
+			want = 10
+		}
+		if v, ok := m.Get(id); i != 1 && (!ok || v != want) {
+			t.Fatalf("Was expecting %d for %s after SortByID, got %d", want, id, v)
+		}
+	}
+}
+
+func TestOrderedMap_JSON(t *testing.T) {
+	ids := randomIDs(3)
+	m := NewOrderedMap[string](3)
+	m.Set(ids[2], "c")
+	m.Set(ids[0], "a")
+	m.Set(ids[1], "b")
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Got error while marshaling %v", err)
+	}
+	expected := `{"` + ids[2].String() + `":"c","` + ids[0].String() + `":"a","` + ids[1].String() + `":"b"}`
+	if string(b) != expected {
+		t.Fatalf("Marshaled %s did not match with %s", b, expected)
+	}
+
+	var decoded OrderedMap[string]
+	if err = json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Got error while unmarshaling %v", err)
+	}
+	if !slices.Equal(decoded.Keys(), m.Keys()) || !slices.Equal(slices.Collect(decoded.Values()), []string{"c", "a", "b"}) {
+		t.Fatalf("Unmarshaled map did not match with original map")
+	}
+	if err = json.Unmarshal([]byte(`null`), &decoded); err != nil || decoded.Len() != 0 {
+		t.Fatalf("Was expecting empty map for null, got %d entries: %v", decoded.Len(), err)
+	}
+	if err = json.Unmarshal([]byte(`{"abc":"a"}`), &decoded); err == nil {
+		t.Fatalf("Was expecting error for invalid key")
+	}
+	if err = json.Unmarshal([]byte(`[]`), &decoded); err == nil {
+		t.Fatalf("Was expecting error for array")
+	}
+}
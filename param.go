@@ -0,0 +1,48 @@
+package idx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrParamMissing is wrapped by ParamError when a path parameter is empty or absent.
+var ErrParamMissing = errors.New("idx: missing path parameter")
+
+// ParamError reports a path parameter that is missing or not a valid ID. The router adapters ginidx,
+// echoidx, chiidx and fiberidx all respond with its message and StatusCode, so clients get the same
+// error whatever the framework.
+type ParamError struct {
+	Name  string
+	Value string
+	Err   error
+}
+
+func (e *ParamError) Error() string {
+	if errors.Is(e.Err, ErrParamMissing) {
+		return fmt.Sprintf("idx: missing path parameter %s", e.Name)
+	}
+	return fmt.Sprintf("idx: path parameter %s: %q is not a valid id", e.Name, e.Value)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns http.StatusBadRequest, the status to respond with.
+func (e *ParamError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// ParseParam parses value, the value of the path parameter name, as a ULID string. Failures are reported
+// as a *ParamError.
+func ParseParam(name, value string) (ID, error) {
+	if value == "" {
+		return NilID, &ParamError{Name: name, Err: ErrParamMissing}
+	}
+	id, err := FromString(value)
+	if err != nil {
+		return NilID, &ParamError{Name: name, Value: value, Err: err}
+	}
+	return id, nil
+}
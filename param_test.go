@@ -0,0 +1,31 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"net/http"
+	"testing"
+)
+
+func TestParseParam(t *testing.T) {
+	id := NewID()
+	if parsed, err := ParseParam("id", id.String()); err != nil || parsed != id {
+		t.Fatalf("Parsed ID (%s) did not match with %s: %v", parsed.String(), id.String(), err)
+	}
+
+	_, err := ParseParam("id", "")
+	var paramErr *ParamError
+	if !errors.As(err, &paramErr) || !errors.Is(err, ErrParamMissing) || paramErr.StatusCode() != http.StatusBadRequest {
+		t.Fatalf("Was expecting missing parameter error, got %v", err)
+	}
+	if err.Error() != "idx: missing path parameter id" {
+		t.Fatalf("Unexpected error message %q", err.Error())
+	}
+	_, err = ParseParam("id", "invalid")
+	if !errors.As(err, &paramErr) || !errors.Is(err, ulid.ErrDataSize) || paramErr.Value != "invalid" {
+		t.Fatalf("Was expecting invalid parameter error, got %v", err)
+	}
+	if err.Error() != `idx: path parameter id: "invalid" is not a valid id` {
+		t.Fatalf("Unexpected error message %q", err.Error())
+	}
+}
@@ -0,0 +1,67 @@
+// Package parquetidx maps idx.ID to Parquet FIXED_LEN_BYTE_ARRAY(16) columns with
+// github.com/parquet-go/parquet-go.
+//
+// Struct fields of type idx.ID are written as FIXED_LEN_BYTE_ARRAY(16) without further configuration.
+// Because the binary order of IDs is their creation order, the min/max statistics of those columns allow
+// readers to skip pages and row groups by time.
+package parquetidx
+
+import (
+	"github.com/ieshan/idx"
+	"github.com/parquet-go/parquet-go"
+)
+
+// Node returns the schema node of an ID column, a FIXED_LEN_BYTE_ARRAY(16) without logical type.
+func Node() parquet.Node {
+	return parquet.Leaf(parquet.FixedLenByteArrayType(len(idx.NilID)))
+}
+
+// UUIDNode returns the schema node of an ID column annotated with the UUID logical type, for readers that
+// expect UUIDs. The stored bytes are the same as with Node.
+func UUIDNode() parquet.Node {
+	return parquet.UUID()
+}
+
+// Value returns id as a Parquet value.
+func Value(id idx.ID) parquet.Value {
+	return parquet.FixedLenByteArrayValue(id[:])
+}
+
+// FromValue returns the ID held by a FIXED_LEN_BYTE_ARRAY(16) value. A null value decodes as idx.NilID.
+func FromValue(v parquet.Value) (idx.ID, error) {
+	var id idx.ID
+	if v.IsNull() {
+		return id, nil
+	}
+	return id, id.UnmarshalBinary(v.ByteArray())
+}
+
+// ChunkBounds returns the smallest and largest ID stored in an ID column chunk, computed from its page index.
+// ok is false when the chunk only holds nulls.
+func ChunkBounds(chunk parquet.ColumnChunk) (min, max idx.ID, ok bool, err error) {
+	index, err := chunk.ColumnIndex()
+	if err != nil {
+		return min, max, false, err
+	}
+	for i := 0; i < index.NumPages(); i++ {
+		if index.NullPage(i) {
+			continue
+		}
+		pageMin, err := FromValue(index.MinValue(i))
+		if err != nil {
+			return min, max, false, err
+		}
+		pageMax, err := FromValue(index.MaxValue(i))
+		if err != nil {
+			return min, max, false, err
+		}
+		if !ok || pageMin.Compare(min) < 0 {
+			min = pageMin
+		}
+		if !ok || pageMax.Compare(max) > 0 {
+			max = pageMax
+		}
+		ok = true
+	}
+	return min, max, ok, nil
+}
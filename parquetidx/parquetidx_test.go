@@ -0,0 +1,74 @@
+package parquetidx
+
+import (
+	"bytes"
+	"github.com/ieshan/idx"
+	"github.com/parquet-go/parquet-go"
+	"testing"
+)
+
+type row struct {
+	ID    idx.ID `parquet:"id"`
+	Value string `parquet:"value"`
+}
+
+func TestNode(t *testing.T) {
+	schema := parquet.SchemaOf(row{})
+	if schema.Fields()[0].Type().String() != Node().Type().String() {
+		t.Fatalf("ID field type %s did not match with %s", schema.Fields()[0].Type(), Node().Type())
+	}
+	if Node().Type().Length() != 16 || Node().Type().Kind() != parquet.FixedLenByteArray {
+		t.Fatalf("Unexpected node type %s", Node().Type())
+	}
+	if UUIDNode().Type().Length() != 16 || UUIDNode().Type().LogicalType().UUID == nil {
+		t.Fatalf("Unexpected UUID node type %s", UUIDNode().Type())
+	}
+
+	id := idx.NewID()
+	result, err := FromValue(Value(id))
+	if err != nil || result != id {
+		t.Fatalf("Original ID (%s) did not match with converted ID (%s): %v", id.String(), result.String(), err)
+	}
+	if result, err = FromValue(parquet.NullValue()); err != nil || result != idx.NilID {
+		t.Fatalf("Was expecting NilID without error, got %s %v", result.String(), err)
+	}
+}
+
+func TestChunkBounds(t *testing.T) {
+	rows := make([]row, 100)
+	for i := range rows {
+		rows[i] = row{ID: idx.NewID(), Value: "test"}
+	}
+
+	var buf bytes.Buffer
+	w := parquet.NewGenericWriter[row](&buf, parquet.PageBufferSize(256))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatalf("Got error while writing rows %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Got error while closing writer %v", err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Got error while opening file %v", err)
+	}
+	min, max, ok, err := ChunkBounds(f.RowGroups()[0].ColumnChunks()[0])
+	if err != nil || !ok {
+		t.Fatalf("Got error while computing bounds %v", err)
+	}
+	if min != rows[0].ID || max != rows[len(rows)-1].ID {
+		t.Fatalf("Bounds %s-%s did not match with %s-%s", min.String(), max.String(), rows[0].ID.String(), rows[len(rows)-1].ID.String())
+	}
+
+	result := make([]row, len(rows))
+	n, err := parquet.NewGenericReader[row](f).Read(result)
+	if n != len(rows) {
+		t.Fatalf("Read %d rows instead of %d: %v", n, len(rows), err)
+	}
+	for i := range rows {
+		if result[i] != rows[i] {
+			t.Fatalf("Row %d %+v did not match with original row %+v", i, result[i], rows[i])
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package idx
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// parallelChunk is the smallest number of values ParseAllParallel hands to a worker.
+const parallelChunk = 4096
+
+// IndexError reports an invalid value at a position of the input of ParseAll.
+type IndexError struct {
+	// Index is the zero-based position of the value.
+	Index int
+	Value string
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("idx: index %d: %q: %v", e.Index, e.Value, e.Err)
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors aggregates the IndexErrors of ParseAll, in input order.
+type ParseErrors []*IndexError
+
+func (e ParseErrors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "idx: %d invalid IDs at indices ", len(e))
+	for i, err := range e {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Itoa(err.Index))
+	}
+	return b.String()
+}
+
+// Unwrap returns the IndexErrors, so errors.Is and errors.As look into them.
+func (e ParseErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ParseAll parses ULID strings with FromString. It returns either all the IDs, or a ParseErrors naming
+// every invalid value:
+//
+//	ids, err := idx.ParseAll(strings.Split(r.URL.Query().Get("ids"), ","))
+func ParseAll(vals []string) ([]ID, error) {
+	ids := make([]ID, len(vals))
+	var errs ParseErrors
+	for i, val := range vals {
+		var err error
+		if ids[i], err = FromString(val); err != nil {
+			errs = append(errs, &IndexError{Index: i, Value: val, Err: err})
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return ids, nil
+}
+
+// ParseAllParallel is ParseAll splitting vals across workers goroutines, for inputs of millions of values.
+// workers defaults to GOMAXPROCS when not positive, and short inputs are parsed on the calling goroutine.
+func ParseAllParallel(vals []string, workers int) ([]ID, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	size := max((len(vals)+workers-1)/workers, parallelChunk)
+	if size >= len(vals) {
+		return ParseAll(vals)
+	}
+	ids := make([]ID, len(vals))
+	var mu sync.Mutex
+	var errs ParseErrors
+	var wg sync.WaitGroup
+	for start := 0; start < len(vals); start += size {
+		end := min(start+size, len(vals))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var chunkErrs ParseErrors
+			for i := start; i < end; i++ {
+				var err error
+				if ids[i], err = FromString(vals[i]); err != nil {
+					chunkErrs = append(chunkErrs, &IndexError{Index: i, Value: vals[i], Err: err})
+				}
+			}
+			if chunkErrs != nil {
+				mu.Lock()
+				errs = append(errs, chunkErrs...)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if errs != nil {
+		slices.SortFunc(errs, func(a, b *IndexError) int { return a.Index - b.Index })
+		return nil, errs
+	}
+	return ids, nil
+}
+
+// Join returns the ULID strings of ids separated by sep, e.g. for query parameters and CSV cells.
+func Join(ids []ID, sep string) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	b := make([]byte, 0, len(ids)*(26+len(sep)))
+	for i, id := range ids {
+		if i > 0 {
+			b = append(b, sep...)
+		}
+		b = id.AppendString(b)
+	}
+	return string(b)
+}
+
+// Split parses a list of ULID strings separated by sep, as produced by Join. Whitespace around the
+// values is ignored and an empty s returns no IDs. Invalid values are reported like ParseAll does.
+func Split(s, sep string) ([]ID, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	vals := strings.Split(s, sep)
+	for i, val := range vals {
+		vals[i] = strings.TrimSpace(val)
+	}
+	return ParseAll(vals)
+}
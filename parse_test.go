@@ -0,0 +1,107 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"slices"
+	"testing"
+)
+
+func TestParseAll(t *testing.T) {
+	a, b := NewID(), NewID()
+	ids, err := ParseAll([]string{a.String(), b.String()})
+	if err != nil || len(ids) != 2 || ids[0] != a || ids[1] != b {
+		t.Fatalf("Parsed IDs %v did not match with %s, %s: %v", ids, a.String(), b.String(), err)
+	}
+	if ids, err = ParseAll(nil); err != nil || len(ids) != 0 {
+		t.Fatalf("Was expecting no IDs, got %v: %v", ids, err)
+	}
+
+	ids, err = ParseAll([]string{a.String(), "short", b.String(), "01HAK8JPF7S0SFMJ2X96W37WXI"})
+	if ids != nil {
+		t.Fatalf("Was expecting no IDs on error, got %v", ids)
+	}
+	var errs ParseErrors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Index != 1 || errs[1].Index != 3 || errs[0].Value != "short" {
+		t.Fatalf("Unexpected errors %v", err)
+	}
+	if err.Error() != "idx: 2 invalid IDs at indices 1, 3" {
+		t.Fatalf("Unexpected error message %q", err.Error())
+	}
+	if !errors.Is(err, ulid.ErrDataSize) || !errors.Is(err, ulid.ErrInvalidCharacters) {
+		t.Fatalf("Was expecting the errors of each value to be wrapped, got %v", err)
+	}
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) || indexErr.Index != 1 {
+		t.Fatalf("Was expecting the first index error, got %v", indexErr)
+	}
+}
+
+func TestParseAllParallel(t *testing.T) {
+	vals := make([]string, 3*parallelChunk+10)
+	expected := make([]ID, len(vals))
+	for i := range vals {
+		expected[i] = NewID()
+		vals[i] = expected[i].String()
+	}
+	for _, workers := range []int{0, 1, 4, 100} {
+		ids, err := ParseAllParallel(vals, workers)
+		if err != nil || !slices.Equal(ids, expected) {
+			t.Fatalf("Parsed IDs did not match with %d workers: %v", workers, err)
+		}
+	}
+
+	vals[parallelChunk*2+5], vals[3] = "invalid", "short"
+	ids, err := ParseAllParallel(vals, 4)
+	var errs ParseErrors
+	if ids != nil || !errors.As(err, &errs) || len(errs) != 2 || errs[0].Index != 3 || errs[1].Index != parallelChunk*2+5 {
+		t.Fatalf("Unexpected errors %v", err)
+	}
+}
+
+func BenchmarkParseAll(b *testing.B) {
+	vals := make([]string, 1_000_000)
+	for i := range vals {
+		vals[i] = NewID().String()
+	}
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := ParseAll(vals); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := ParseAllParallel(vals, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestJoin(t *testing.T) {
+	ids := []ID{NewID(), NewID(), NewID()}
+	s := Join(ids, ",")
+	if s != ids[0].String()+","+ids[1].String()+","+ids[2].String() {
+		t.Fatalf("Unexpected list %q", s)
+	}
+	if Join(nil, ",") != "" || Join(ids[:1], ",") != ids[0].String() {
+		t.Fatalf("Unexpected list for short input")
+	}
+
+	split, err := Split(s, ",")
+	if err != nil || !slices.Equal(split, ids) {
+		t.Fatalf("Split IDs %v did not match with %v: %v", split, ids, err)
+	}
+	if split, err = Split(" "+ids[0].String()+" ; "+ids[1].String(), ";"); err != nil || !slices.Equal(split, ids[:2]) {
+		t.Fatalf("Split IDs %v did not match with %v: %v", split, ids[:2], err)
+	}
+	if split, err = Split(" ", ","); err != nil || split != nil {
+		t.Fatalf("Was expecting no IDs, got %v: %v", split, err)
+	}
+	var errs ParseErrors
+	if _, err = Split(s+",", ","); !errors.As(err, &errs) || errs[0].Index != 3 {
+		t.Fatalf("Was expecting error for the trailing empty value, got %v", err)
+	}
+}
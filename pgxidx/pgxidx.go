@@ -0,0 +1,86 @@
+// Package pgxidx binds idx.ID values to PostgreSQL uuid columns with pgx
+// (https://pkg.go.dev/github.com/jackc/pgx/v5), using the binary protocol instead of the database/sql
+// Valuer and Scanner.
+package pgxidx
+
+import (
+	"github.com/ieshan/idx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Register registers the Codec for the uuid and uuid[] types on conn. It is typically called from
+// pgxpool.Config.AfterConnect.
+func Register(conn *pgx.Conn) {
+	RegisterTypes(conn.TypeMap())
+}
+
+// RegisterTypes registers the Codec for the uuid and uuid[] types on m, so that idx.ID, *idx.ID and
+// []idx.ID values bind to uuid parameters, including `= ANY($1)` queries, and uuid columns scan into
+// them.
+func RegisterTypes(m *pgtype.Map) {
+	t := &pgtype.Type{Name: "uuid", OID: pgtype.UUIDOID, Codec: Codec{}}
+	m.RegisterType(t)
+	m.RegisterType(&pgtype.Type{Name: "_uuid", OID: pgtype.UUIDArrayOID, Codec: &pgtype.ArrayCodec{ElementType: t}})
+	m.RegisterDefaultPgType(idx.ID{}, t.Name)
+	m.RegisterDefaultPgType([]idx.ID{}, "_uuid")
+}
+
+// Codec extends pgtype.UUIDCodec with support for idx.ID. The ID bytes are stored unchanged, and NilID is
+// written as NULL like ID.Value does.
+type Codec struct {
+	pgtype.UUIDCodec
+}
+
+// PlanEncode implements pgtype.Codec.
+func (c Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case idx.ID, *idx.ID:
+		if next := c.UUIDCodec.PlanEncode(m, oid, format, pgtype.UUID{}); next != nil {
+			return encodePlan{next: next}
+		}
+		return nil
+	}
+	return c.UUIDCodec.PlanEncode(m, oid, format, value)
+}
+
+// PlanScan implements pgtype.Codec.
+func (c Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if _, ok := target.(*idx.ID); ok {
+		if next := c.UUIDCodec.PlanScan(m, oid, format, &pgtype.UUID{}); next != nil {
+			return scanPlan{next: next}
+		}
+		return nil
+	}
+	return c.UUIDCodec.PlanScan(m, oid, format, target)
+}
+
+type encodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p encodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	var id idx.ID
+	switch v := value.(type) {
+	case idx.ID:
+		id = v
+	case *idx.ID:
+		if v != nil {
+			id = *v
+		}
+	}
+	return p.next.Encode(pgtype.UUID{Bytes: id, Valid: id != idx.NilID}, buf)
+}
+
+type scanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (p scanPlan) Scan(src []byte, target any) error {
+	var u pgtype.UUID
+	if err := p.next.Scan(src, &u); err != nil {
+		return err
+	}
+	*target.(*idx.ID) = u.Bytes
+	return nil
+}
@@ -0,0 +1,65 @@
+package pgxidx
+
+import (
+	"github.com/ieshan/idx"
+	"github.com/jackc/pgx/v5/pgtype"
+	"slices"
+	"testing"
+)
+
+func TestRegisterTypes(t *testing.T) {
+	m := pgtype.NewMap()
+	RegisterTypes(m)
+	id := idx.NewID()
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.UUIDOID, format, id, nil)
+		if err != nil {
+			t.Fatalf("Got error while encoding ID %v", err)
+		}
+		if format == pgtype.TextFormatCode && string(buf) != id.UUIDString() {
+			t.Fatalf("Encoded value %s did not match with %s", buf, id.UUIDString())
+		}
+		var result idx.ID
+		if err = m.Scan(pgtype.UUIDOID, format, buf, &result); err != nil {
+			t.Fatalf("Got error while scanning ID %v", err)
+		}
+		if result != id {
+			t.Fatalf("Original ID (%s) did not match with scanned ID (%s)", id.String(), result.String())
+		}
+
+		var ptr *idx.ID
+		if err = m.Scan(pgtype.UUIDOID, format, buf, &ptr); err != nil || ptr == nil || *ptr != id {
+			t.Fatalf("Scanned pointer %v did not match with %s: %v", ptr, id.String(), err)
+		}
+		if err = m.Scan(pgtype.UUIDOID, format, nil, &ptr); err != nil || ptr != nil {
+			t.Fatalf("Was expecting nil pointer for NULL, got %v: %v", ptr, err)
+		}
+	}
+
+	if buf, err := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, idx.NilID, nil); err != nil || buf != nil {
+		t.Fatalf("Was expecting NULL for NilID, got %v: %v", buf, err)
+	}
+	if buf, err := m.Encode(pgtype.UUIDOID, pgtype.BinaryFormatCode, (*idx.ID)(nil), nil); err != nil || buf != nil {
+		t.Fatalf("Was expecting NULL for nil pointer, got %v: %v", buf, err)
+	}
+	if dt, ok := m.TypeForValue(id); !ok || dt.OID != pgtype.UUIDOID {
+		t.Fatalf("ID is not mapped to uuid by default")
+	}
+}
+
+func TestRegisterTypes_Array(t *testing.T) {
+	m := pgtype.NewMap()
+	RegisterTypes(m)
+	ids := []idx.ID{idx.NewID(), idx.NewID()}
+	buf, err := m.Encode(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, ids, nil)
+	if err != nil {
+		t.Fatalf("Got error while encoding IDs %v", err)
+	}
+	var result []idx.ID
+	if err = m.Scan(pgtype.UUIDArrayOID, pgtype.BinaryFormatCode, buf, &result); err != nil {
+		t.Fatalf("Got error while scanning IDs %v", err)
+	}
+	if !slices.Equal(result, ids) {
+		t.Fatalf("Scanned IDs %v did not match with %v", result, ids)
+	}
+}
@@ -0,0 +1,65 @@
+package idx
+
+import (
+	"github.com/oklog/ulid/v2"
+	"strings"
+)
+
+// ProquintEncodedSize is the length of the proquint form of an ID: eight five-letter words separated by
+// dashes.
+const ProquintEncodedSize = 8*5 + 7
+
+const (
+	proquintConsonants = "bdfghjklmnprstvz"
+	proquintVowels     = "aiou"
+)
+
+// Proquint returns the ID as eight pronounceable consonant-vowel words (https://arxiv.org/abs/0901.4016),
+// such as "lusab-babad-...", which are easier to read out over the phone than Crockford base32.
+func (id ID) Proquint() string {
+	var dst [ProquintEncodedSize]byte
+	for i := 0; i < 8; i++ {
+		v := uint16(id[2*i])<<8 | uint16(id[2*i+1])
+		b := dst[i*6:]
+		b[0] = proquintConsonants[v>>12]
+		b[1] = proquintVowels[v>>10&3]
+		b[2] = proquintConsonants[v>>6&15]
+		b[3] = proquintVowels[v>>4&3]
+		b[4] = proquintConsonants[v&15]
+		if i < 7 {
+			b[5] = '-'
+		}
+	}
+	return string(dst[:])
+}
+
+// FromProquint parses a string produced by Proquint, in either case.
+func FromProquint(val string) (ID, error) {
+	var id ID
+	if len(val) != ProquintEncodedSize {
+		return id, ulid.ErrDataSize
+	}
+	for i := 0; i < 8; i++ {
+		w := val[i*6:]
+		if i < 7 && w[5] != '-' {
+			return NilID, ulid.ErrInvalidCharacters
+		}
+		var v uint16
+		for j := 0; j < 5; j++ {
+			c := w[j] | 0x20
+			var d int
+			if j%2 == 0 {
+				d = strings.IndexByte(proquintConsonants, c)
+				v = v<<4 | uint16(d)
+			} else {
+				d = strings.IndexByte(proquintVowels, c)
+				v = v<<2 | uint16(d)
+			}
+			if d < 0 {
+				return NilID, ulid.ErrInvalidCharacters
+			}
+		}
+		id[2*i], id[2*i+1] = byte(v>>8), byte(v)
+	}
+	return id, nil
+}
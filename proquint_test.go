@@ -0,0 +1,46 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestID_Proquint(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		quint := id.Proquint()
+		if len(quint) != ProquintEncodedSize {
+			t.Fatalf("Proquint value %s of %s is not %d characters long", quint, id.String(), ProquintEncodedSize)
+		}
+		decoded, err := FromProquint(strings.ToUpper(quint))
+		if err != nil {
+			t.Fatalf("Got error while decoding %s: %v", quint, err)
+		}
+		if decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+		}
+	}
+	// 127.0.0.1 and 63.84.220.193 from the proquint specification.
+	id := ID{127, 0, 0, 1, 63, 84, 220, 193}
+	if !strings.HasPrefix(id.Proquint(), "lusab-babad-gutih-tugad-") {
+		t.Fatalf("Unexpected proquint value %s", id.Proquint())
+	}
+}
+
+func TestFromProquint(t *testing.T) {
+	valid := NilID.Proquint()
+	invalid := map[string]error{
+		"":                                  ulid.ErrDataSize,
+		valid[:46]:                          ulid.ErrDataSize,
+		strings.Replace(valid, "-", "_", 1): ulid.ErrInvalidCharacters,
+		"x" + valid[1:]:                     ulid.ErrInvalidCharacters,
+		valid[:1] + "e" + valid[2:]:         ulid.ErrInvalidCharacters,
+	}
+	for val, expected := range invalid {
+		if _, err := FromProquint(val); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package idx
+
+import (
+	"github.com/oklog/ulid/v2"
+)
+
+// schemaPattern matches ULID strings in either case, as accepted by UnmarshalJSON.
+const schemaPattern = "^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$"
+
+// schemaExample is the example value used in generated schemas.
+const schemaExample = "01HAK8JPF7S0SFMJ2X96W37WXB"
+
+// Schema is a schema fragment describing the JSON representation of an ID. It marshals to JSON directly
+// and can be embedded into generated API definitions.
+type Schema struct {
+	Type        string   `json:"type"`
+	Format      string   `json:"format,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Pattern     string   `json:"pattern"`
+	MinLength   int      `json:"minLength"`
+	MaxLength   int      `json:"maxLength"`
+	Example     string   `json:"example,omitempty"`
+	Examples    []string `json:"examples,omitempty"`
+}
+
+// JSONSchema returns the JSON Schema (draft 2020-12) fragment for an ID field.
+func JSONSchema() Schema {
+	return Schema{
+		Type:        "string",
+		Description: "ULID encoded as 26 Crockford base32 characters",
+		Pattern:     schemaPattern,
+		MinLength:   ulid.EncodedSize,
+		MaxLength:   ulid.EncodedSize,
+		Examples:    []string{schemaExample},
+	}
+}
+
+// OpenAPISchema returns the OpenAPI 3.1 schema object for an ID field. It extends JSONSchema with the
+// "ulid" format and the example keyword understood by OpenAPI tooling.
+func OpenAPISchema() Schema {
+	s := JSONSchema()
+	s.Format = "ulid"
+	s.Example = schemaExample
+	return s
+}
@@ -0,0 +1,44 @@
+package idx
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	b, err := json.Marshal(JSONSchema())
+	if err != nil {
+		t.Fatalf("Got error while marshaling schema %v", err)
+	}
+	expected := `{"type":"string","description":"ULID encoded as 26 Crockford base32 characters",` +
+		`"pattern":"^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$","minLength":26,"maxLength":26,` +
+		`"examples":["01HAK8JPF7S0SFMJ2X96W37WXB"]}`
+	if string(b) != expected {
+		t.Fatalf("Schema %s did not match with expectation %s", string(b), expected)
+	}
+
+	pattern := regexp.MustCompile(JSONSchema().Pattern)
+	for i := 0; i < 100; i++ {
+		id := NewID()
+		if !pattern.MatchString(id.String()) || !pattern.MatchString(strings.ToLower(id.String())) {
+			t.Fatalf("Pattern did not match ID %s", id.String())
+		}
+	}
+	for _, val := range []string{"wrong", "01HAJ2Q3T69IJMMBDNAMVZ3FQB", "81HAK8JPF7S0SFMJ2X96W37WXB", "01HAK8JPF7S0SFMJ2X96W37WXBB"} {
+		if pattern.MatchString(val) || IsValidID(val) {
+			t.Fatalf("Pattern matched invalid value %s", val)
+		}
+	}
+	if !IsValidID(schemaExample) {
+		t.Fatalf("Schema example %s is not a valid ID", schemaExample)
+	}
+}
+
+func TestOpenAPISchema(t *testing.T) {
+	s := OpenAPISchema()
+	if s.Type != "string" || s.Format != "ulid" || s.Example != schemaExample || s.Pattern != schemaPattern {
+		t.Fatalf("Unexpected OpenAPI schema %+v", s)
+	}
+}
@@ -0,0 +1,83 @@
+package idx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// scrambleRounds is the number of Feistel rounds applied by a Scrambler.
+const scrambleRounds = 8
+
+// Scrambler is a keyed, reversible permutation of IDs built from a balanced Feistel network with an
+// HMAC-SHA256 round function. It allows publishing IDs in a form that cannot be linked back to the
+// internal value, or its creation time, without the key. It is safe for concurrent use.
+type Scrambler struct {
+	key []byte
+}
+
+// NewScrambler returns a Scrambler keyed with key.
+func NewScrambler(key []byte) *Scrambler {
+	return &Scrambler{key: append([]byte(nil), key...)}
+}
+
+// Scramble maps id to its pseudonymous form. The mapping is a bijection, so distinct IDs never collide.
+func (s *Scrambler) Scramble(id ID) ID {
+	s.encrypt(id[:], nil)
+	return id
+}
+
+// Unscramble reverses Scramble.
+func (s *Scrambler) Unscramble(id ID) ID {
+	s.decrypt(id[:], nil)
+	return id
+}
+
+// ScrambleEntropy maps id to a pseudonymous form that keeps the 48-bit timestamp intact and only
+// permutes the 80-bit entropy, so the result still sorts by creation time. The timestamp is used as a
+// tweak, which means equal entropy under different timestamps scrambles to unrelated values.
+func (s *Scrambler) ScrambleEntropy(id ID) ID {
+	s.encrypt(id[6:], id[:6])
+	return id
+}
+
+// UnscrambleEntropy reverses ScrambleEntropy.
+func (s *Scrambler) UnscrambleEntropy(id ID) ID {
+	s.decrypt(id[6:], id[:6])
+	return id
+}
+
+// encrypt permutes block in place, using tweak as additional input to every round.
+// block must have an even length.
+func (s *Scrambler) encrypt(block, tweak []byte) {
+	half := len(block) / 2
+	l, r := block[:half], block[half:]
+	for round := 0; round < scrambleRounds; round++ {
+		xorBytes(l, s.round(round, tweak, r))
+		l, r = r, l
+	}
+	// An even number of rounds leaves the halves in their original positions, so no final swap is needed
+}
+
+// decrypt reverses encrypt.
+func (s *Scrambler) decrypt(block, tweak []byte) {
+	half := len(block) / 2
+	l, r := block[half:], block[:half]
+	for round := scrambleRounds - 1; round >= 0; round-- {
+		xorBytes(l, s.round(round, tweak, r))
+		l, r = r, l
+	}
+}
+
+func (s *Scrambler) round(round int, tweak, half []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte{byte(round)})
+	mac.Write(tweak)
+	mac.Write(half)
+	return mac.Sum(nil)[:len(half)]
+}
+
+func xorBytes(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
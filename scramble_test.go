@@ -0,0 +1,63 @@
+package idx
+
+import (
+	"testing"
+)
+
+func TestScrambler(t *testing.T) {
+	scrambler := NewScrambler([]byte("secret"))
+	seen := make(map[ID]struct{})
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		scrambled := scrambler.Scramble(id)
+		if scrambled == id {
+			t.Fatalf("Scrambled ID (%s) is equal to the original ID", scrambled.String())
+		}
+		if _, ok := seen[scrambled]; ok {
+			t.Fatalf("Scrambled ID (%s) collided", scrambled.String())
+		}
+		seen[scrambled] = struct{}{}
+		if unscrambled := scrambler.Unscramble(scrambled); unscrambled != id {
+			t.Fatalf("Original ID (%s) did not match with unscrambled ID (%s)", id.String(), unscrambled.String())
+		}
+	}
+
+	id := NewID()
+	if NewScrambler([]byte("other")).Scramble(id) == scrambler.Scramble(id) {
+		t.Fatalf("Different keys produced the same scrambled ID")
+	}
+	if scrambler.Scramble(NilID) == NilID {
+		t.Fatalf("NilID should not be a fixed point")
+	}
+}
+
+func TestScrambler_ScrambleEntropy(t *testing.T) {
+	scrambler := NewScrambler([]byte("secret"))
+	seen := make(map[ID]struct{})
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		scrambled := scrambler.ScrambleEntropy(id)
+		if scrambled == id {
+			t.Fatalf("Scrambled ID (%s) is equal to the original ID", scrambled.String())
+		}
+		if [6]byte(scrambled[:6]) != [6]byte(id[:6]) {
+			t.Fatalf("Scrambled ID (%s) did not preserve the timestamp of %s", scrambled.String(), id.String())
+		}
+		if _, ok := seen[scrambled]; ok {
+			t.Fatalf("Scrambled ID (%s) collided", scrambled.String())
+		}
+		seen[scrambled] = struct{}{}
+		if unscrambled := scrambler.UnscrambleEntropy(scrambled); unscrambled != id {
+			t.Fatalf("Original ID (%s) did not match with unscrambled ID (%s)", id.String(), unscrambled.String())
+		}
+	}
+
+	// The same entropy under a different timestamp must not scramble to the same entropy
+	a, b := NewID(), NewID()
+	copy(b[6:], a[6:])
+	b[0]++
+	sa, sb := scrambler.ScrambleEntropy(a), scrambler.ScrambleEntropy(b)
+	if [10]byte(sa[6:]) == [10]byte(sb[6:]) {
+		t.Fatalf("Entropy scrambled identically under different timestamps")
+	}
+}
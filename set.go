@@ -0,0 +1,260 @@
+package idx
+
+import (
+	"encoding/binary"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// CompactSet is a set of IDs inspired by roaring bitmaps, for tens of millions of IDs. IDs are grouped into
+// containers by the high 32 bits of their timestamp, about 65 seconds, and containers keep the remaining
+// 96 bits in sorted arrays, so each ID takes 12 bytes once Trim released the spare capacity of the arrays,
+// against 20 or more in a map[ID]struct{}. Adding IDs in increasing order, as generated, appends to the
+// last container.
+//
+// The zero value is an empty set ready to use. A CompactSet is not safe for concurrent modification.
+type CompactSet struct {
+	keys       []uint32
+	containers []*setContainer
+	n          int
+}
+
+// setContainer holds the low 16 bits of the timestamp and the 16 high entropy bits of each ID in hi,
+// and the 64 low entropy bits in lo, sorted together.
+type setContainer struct {
+	hi []uint32
+	lo []uint64
+}
+
+// NewCompactSet returns a set holding ids.
+func NewCompactSet(ids ...ID) *CompactSet {
+	s := &CompactSet{}
+	for _, id := range ids {
+		s.Add(id)
+	}
+	return s
+}
+
+func splitSetID(id ID) (uint32, uint32, uint64) {
+	return binary.BigEndian.Uint32(id[:4]), binary.BigEndian.Uint32(id[4:8]), binary.BigEndian.Uint64(id[8:])
+}
+
+func joinSetID(key, hi uint32, lo uint64) ID {
+	var id ID
+	binary.BigEndian.PutUint32(id[:4], key)
+	binary.BigEndian.PutUint32(id[4:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id
+}
+
+// Len returns the number of IDs in the set.
+func (s *CompactSet) Len() int {
+	return s.n
+}
+
+// Add adds id to the set and reports whether it was missing.
+func (s *CompactSet) Add(id ID) bool {
+	key, hi, lo := splitSetID(id)
+	i, found := s.container(key)
+	if !found {
+		s.keys = slices.Insert(s.keys, i, key)
+		s.containers = slices.Insert(s.containers, i, &setContainer{})
+	}
+	if !s.containers[i].add(hi, lo) {
+		return false
+	}
+	s.n++
+	return true
+}
+
+// Remove removes id from the set and reports whether it was present.
+func (s *CompactSet) Remove(id ID) bool {
+	key, hi, lo := splitSetID(id)
+	i, found := s.container(key)
+	if !found {
+		return false
+	}
+	c := s.containers[i]
+	j, found := c.search(hi, lo)
+	if !found {
+		return false
+	}
+	c.hi, c.lo = slices.Delete(c.hi, j, j+1), slices.Delete(c.lo, j, j+1)
+	if len(c.hi) == 0 {
+		s.keys, s.containers = slices.Delete(s.keys, i, i+1), slices.Delete(s.containers, i, i+1)
+	}
+	s.n--
+	return true
+}
+
+// Contains reports whether id is in the set.
+func (s *CompactSet) Contains(id ID) bool {
+	key, hi, lo := splitSetID(id)
+	i, found := s.container(key)
+	if !found {
+		return false
+	}
+	_, found = s.containers[i].search(hi, lo)
+	return found
+}
+
+// Trim releases the spare capacity of the containers, e.g. once the set is fully loaded.
+func (s *CompactSet) Trim() {
+	for _, c := range s.containers {
+		if cap(c.hi) > len(c.hi) {
+			c.hi, c.lo = slices.Clone(c.hi), slices.Clone(c.lo)
+		}
+	}
+}
+
+// All returns an iterator over the IDs of the set in increasing order.
+func (s *CompactSet) All() iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		for i, c := range s.containers {
+			for j := range c.hi {
+				if !yield(joinSetID(s.keys[i], c.hi[j], c.lo[j])) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Union returns a new set holding the IDs of s and other.
+func (s *CompactSet) Union(other *CompactSet) *CompactSet {
+	out := &CompactSet{}
+	i, j := 0, 0
+	for i < len(s.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(s.keys) && s.keys[i] < other.keys[j]):
+			out.append(s.keys[i], s.containers[i].clone())
+			i++
+		case i == len(s.keys) || other.keys[j] < s.keys[i]:
+			out.append(other.keys[j], other.containers[j].clone())
+			j++
+		default:
+			out.append(s.keys[i], s.containers[i].merge(other.containers[j], true))
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// Intersect returns a new set holding the IDs present in both s and other.
+func (s *CompactSet) Intersect(other *CompactSet) *CompactSet {
+	out := &CompactSet{}
+	i, j := 0, 0
+	for i < len(s.keys) && j < len(other.keys) {
+		switch {
+		case s.keys[i] < other.keys[j]:
+			i++
+		case other.keys[j] < s.keys[i]:
+			j++
+		default:
+			out.append(s.keys[i], s.containers[i].merge(other.containers[j], false))
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// MarshalBinary encodes the set with EncodeSorted. See https://pkg.go.dev/encoding#BinaryMarshaler
+func (s *CompactSet) MarshalBinary() ([]byte, error) {
+	return EncodeSorted(slices.Collect(s.All()))
+}
+
+// UnmarshalBinary replaces the content of the set with the IDs encoded by MarshalBinary.
+// See https://pkg.go.dev/encoding#BinaryUnmarshaler
+func (s *CompactSet) UnmarshalBinary(b []byte) error {
+	ids, err := DecodeSorted(b)
+	if err != nil {
+		return err
+	}
+	*s = CompactSet{}
+	for _, id := range ids {
+		s.Add(id)
+	}
+	return nil
+}
+
+// container returns the position of the container of key and whether it exists.
+func (s *CompactSet) container(key uint32) (int, bool) {
+	// IDs mostly arrive in increasing order, so the last container is checked first.
+	if n := len(s.keys); n > 0 && s.keys[n-1] <= key {
+		return n - 1 + boolInt(s.keys[n-1] < key), s.keys[n-1] == key
+	}
+	return slices.BinarySearch(s.keys, key)
+}
+
+// append adds a container after the existing ones, dropping empty containers.
+func (s *CompactSet) append(key uint32, c *setContainer) {
+	if len(c.hi) == 0 {
+		return
+	}
+	s.keys = append(s.keys, key)
+	s.containers = append(s.containers, c)
+	s.n += len(c.hi)
+}
+
+func (c *setContainer) search(hi uint32, lo uint64) (int, bool) {
+	i := sort.Search(len(c.hi), func(i int) bool {
+		return c.hi[i] > hi || (c.hi[i] == hi && c.lo[i] >= lo)
+	})
+	return i, i < len(c.hi) && c.hi[i] == hi && c.lo[i] == lo
+}
+
+func (c *setContainer) add(hi uint32, lo uint64) bool {
+	if n := len(c.hi); n == 0 || c.hi[n-1] < hi || (c.hi[n-1] == hi && c.lo[n-1] < lo) {
+		c.hi, c.lo = append(c.hi, hi), append(c.lo, lo)
+		return true
+	}
+	i, found := c.search(hi, lo)
+	if found {
+		return false
+	}
+	c.hi, c.lo = slices.Insert(c.hi, i, hi), slices.Insert(c.lo, i, lo)
+	return true
+}
+
+func (c *setContainer) clone() *setContainer {
+	return &setContainer{hi: slices.Clone(c.hi), lo: slices.Clone(c.lo)}
+}
+
+// merge returns the union of c and other, or their intersection when union is false.
+func (c *setContainer) merge(other *setContainer, union bool) *setContainer {
+	out := &setContainer{}
+	i, j := 0, 0
+	for i < len(c.hi) && j < len(other.hi) {
+		switch {
+		case c.hi[i] < other.hi[j] || (c.hi[i] == other.hi[j] && c.lo[i] < other.lo[j]):
+			if union {
+				out.hi, out.lo = append(out.hi, c.hi[i]), append(out.lo, c.lo[i])
+			}
+			i++
+		case other.hi[j] < c.hi[i] || (other.hi[j] == c.hi[i] && other.lo[j] < c.lo[i]):
+			if union {
+				out.hi, out.lo = append(out.hi, other.hi[j]), append(out.lo, other.lo[j])
+			}
+			j++
+		default:
+			out.hi, out.lo = append(out.hi, c.hi[i]), append(out.lo, c.lo[i])
+			i++
+			j++
+		}
+	}
+	if union {
+		out.hi, out.lo = append(out.hi, c.hi[i:]...), append(out.lo, c.lo[i:]...)
+		out.hi, out.lo = append(out.hi, other.hi[j:]...), append(out.lo, other.lo[j:]...)
+	}
+	return out
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
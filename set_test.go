@@ -0,0 +1,95 @@
+package idx
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompactSet(t *testing.T) {
+	ids := randomIDs(5000)
+	s := NewCompactSet()
+	for _, id := range ids {
+		if !s.Add(id) {
+			t.Fatalf("Was expecting %s to be added", id.String())
+		}
+	}
+	s.Trim()
+	if s.Add(ids[10]) || s.Len() != len(ids) {
+		t.Fatalf("Duplicate ID was added, set holds %d IDs", s.Len())
+	}
+	for _, id := range ids {
+		if !s.Contains(id) {
+			t.Fatalf("Set does not contain %s", id.String())
+		}
+	}
+	if s.Contains(NewID()) {
+		t.Fatalf("Set contains an ID that was not added")
+	}
+	sorted := slices.Clone(ids)
+	SortIDs(sorted)
+	if !slices.Equal(slices.Collect(s.All()), sorted) {
+		t.Fatalf("Set IDs are not returned in order")
+	}
+
+	if !s.Remove(ids[0]) || s.Remove(ids[0]) || s.Contains(ids[0]) || s.Len() != len(ids)-1 {
+		t.Fatalf("Removed ID is still present")
+	}
+	var empty CompactSet
+	if empty.Contains(ids[0]) || empty.Remove(ids[0]) || !empty.Add(ids[0]) || empty.Len() != 1 {
+		t.Fatalf("Zero value set is not usable")
+	}
+	if !empty.Remove(ids[0]) || len(empty.keys) != 0 {
+		t.Fatalf("Empty containers should be dropped")
+	}
+}
+
+func TestCompactSet_Operations(t *testing.T) {
+	ids := randomIDs(3000)
+	a, b := NewCompactSet(ids[:2000]...), NewCompactSet(ids[1000:]...)
+	union, inter := a.Union(b), a.Intersect(b)
+	if union.Len() != 3000 || inter.Len() != 1000 {
+		t.Fatalf("Unexpected union of %d IDs and intersection of %d IDs", union.Len(), inter.Len())
+	}
+	for i, id := range ids {
+		if !union.Contains(id) || inter.Contains(id) != (i >= 1000 && i < 2000) {
+			t.Fatalf("Unexpected membership of %s at %d", id.String(), i)
+		}
+	}
+	if a.Len() != 2000 || b.Len() != 2000 {
+		t.Fatalf("Operations modified their operands")
+	}
+	if n := a.Intersect(NewCompactSet(NewID())).Len(); n != 0 {
+		t.Fatalf("Was expecting an empty intersection, got %d IDs", n)
+	}
+}
+
+func TestCompactSet_MarshalBinary(t *testing.T) {
+	ids := randomIDs(2000)
+	s := NewCompactSet(ids...)
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Got error while encoding set %v", err)
+	}
+	var result CompactSet
+	if err = result.UnmarshalBinary(b); err != nil {
+		t.Fatalf("Got error while decoding set %v", err)
+	}
+	if !slices.Equal(slices.Collect(result.All()), slices.Collect(s.All())) {
+		t.Fatalf("Decoded set did not match with the original set")
+	}
+	if err = result.UnmarshalBinary(b[:len(b)/2]); err == nil {
+		t.Fatalf("Was expecting error for truncated input")
+	}
+}
+
+func BenchmarkCompactSet_Add(b *testing.B) {
+	ids := randomIDs(1_000_000)
+	SortIDs(ids)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := NewCompactSet()
+		for _, id := range ids {
+			s.Add(id)
+		}
+	}
+}
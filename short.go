@@ -0,0 +1,31 @@
+package idx
+
+import (
+	"encoding/base64"
+	"github.com/oklog/ulid/v2"
+)
+
+// ShortEncodedSize is the length of the short form of an ID.
+const ShortEncodedSize = 22
+
+var shortEncoding = base64.RawURLEncoding.Strict()
+
+// Short returns the unpadded base64url encoding of the ID bytes, a 22-character form suited for QR codes
+// and SMS links.
+func (id ID) Short() string {
+	var dst [ShortEncodedSize]byte
+	shortEncoding.Encode(dst[:], id[:])
+	return string(dst[:])
+}
+
+// FromShort parses a string produced by Short.
+func FromShort(val string) (ID, error) {
+	var id ID
+	if len(val) != ShortEncodedSize {
+		return id, ulid.ErrDataSize
+	}
+	if _, err := shortEncoding.Decode(id[:], []byte(val)); err != nil {
+		return NilID, ulid.ErrInvalidCharacters
+	}
+	return id, nil
+}
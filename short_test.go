@@ -0,0 +1,41 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"testing"
+)
+
+func TestID_Short(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		short := id.Short()
+		if len(short) != ShortEncodedSize {
+			t.Fatalf("Short value %s of %s is not %d characters long", short, id.String(), ShortEncodedSize)
+		}
+		decoded, err := FromShort(short)
+		if err != nil {
+			t.Fatalf("Got error while decoding %s: %v", short, err)
+		}
+		if decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+		}
+	}
+	if NilID.Short() != "AAAAAAAAAAAAAAAAAAAAAA" {
+		t.Fatalf("Unexpected short value for NilID %s", NilID.Short())
+	}
+}
+
+func TestFromShort(t *testing.T) {
+	invalid := map[string]error{
+		"":                         ulid.ErrDataSize,
+		"AAAAAAAAAAAAAAAAAAAAAA==": ulid.ErrDataSize,
+		"AAAAAAAAAAAAAAAAAAAAA+":   ulid.ErrInvalidCharacters,
+		"AAAAAAAAAAAAAAAAAAAAAB":   ulid.ErrInvalidCharacters,
+	}
+	for val, expected := range invalid {
+		if _, err := FromShort(val); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package idx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/oklog/ulid/v2"
+)
+
+// signatureSize is the number of HMAC-SHA256 bytes kept in a signed token.
+const signatureSize = 16
+
+// ErrSignature is returned when a signed token has been tampered with or was signed with a different key.
+var ErrSignature = errors.New("idx: invalid signature")
+
+// SignedEncoder renders IDs as tamper-evident tokens of the form "<ulid>.<mac>", where mac is the
+// unpadded base64url encoding of a truncated HMAC-SHA256 of the ID bytes. It is safe for concurrent use.
+type SignedEncoder struct {
+	key []byte
+}
+
+// NewSignedEncoder returns a SignedEncoder using key for signing and verification.
+func NewSignedEncoder(key []byte) *SignedEncoder {
+	return &SignedEncoder{key: append([]byte(nil), key...)}
+}
+
+// Encode returns the signed token for id.
+func (e *SignedEncoder) Encode(id ID) string {
+	sig := e.sign(id)
+	dst := make([]byte, ulid.EncodedSize+1+base64.RawURLEncoding.EncodedLen(signatureSize))
+	_ = ulid.ULID(id).MarshalTextTo(dst[:ulid.EncodedSize])
+	dst[ulid.EncodedSize] = '.'
+	base64.RawURLEncoding.Encode(dst[ulid.EncodedSize+1:], sig)
+	return string(dst)
+}
+
+// Decode verifies a token produced by Encode and returns the ID it carries.
+// ErrSignature is returned when the signature does not match.
+func (e *SignedEncoder) Decode(token string) (ID, error) {
+	if len(token) != ulid.EncodedSize+1+base64.RawURLEncoding.EncodedLen(signatureSize) || token[ulid.EncodedSize] != '.' {
+		return NilID, ulid.ErrDataSize
+	}
+	id, err := FromString(token[:ulid.EncodedSize])
+	if err != nil {
+		return NilID, err
+	}
+	// Strict decoding rejects non-zero trailing bits, so each ID has a single valid token.
+	sig, err := base64.RawURLEncoding.Strict().DecodeString(token[ulid.EncodedSize+1:])
+	if err != nil {
+		return NilID, ErrSignature
+	}
+	if !hmac.Equal(sig, e.sign(id)) {
+		return NilID, ErrSignature
+	}
+	return id, nil
+}
+
+func (e *SignedEncoder) sign(id ID) []byte {
+	mac := hmac.New(sha256.New, e.key)
+	mac.Write(id[:])
+	return mac.Sum(nil)[:signatureSize]
+}
@@ -0,0 +1,46 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestSignedEncoder(t *testing.T) {
+	encoder := NewSignedEncoder([]byte("secret"))
+	id := NewID()
+	token := encoder.Encode(id)
+	if !strings.HasPrefix(token, id.String()+".") {
+		t.Fatalf("Token (%s) does not start with the ID (%s)", token, id.String())
+	}
+	decoded, err := encoder.Decode(token)
+	if err != nil {
+		t.Fatalf("Got error while decoding token %v", err)
+	}
+	if decoded != id {
+		t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+	}
+
+	other := NewID()
+	forged := other.String() + token[ulid.EncodedSize:]
+	if _, err = encoder.Decode(forged); !errors.Is(err, ErrSignature) {
+		t.Fatalf("Was expecting signature error, got %v", err)
+	}
+	if _, err = NewSignedEncoder([]byte("other")).Decode(token); !errors.Is(err, ErrSignature) {
+		t.Fatalf("Was expecting signature error for a different key, got %v", err)
+	}
+	if _, err = encoder.Decode(id.String()); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+	if _, err = encoder.Decode(token[:len(token)-1] + "*"); !errors.Is(err, ErrSignature) {
+		t.Fatalf("Was expecting signature error for malformed signature, got %v", err)
+	}
+
+	// The last character carries 4 unused bits, which must not yield another valid token.
+	const alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+	last := strings.IndexByte(alphabet, token[len(token)-1])
+	if _, err = encoder.Decode(token[:len(token)-1] + string(alphabet[last^1])); !errors.Is(err, ErrSignature) {
+		t.Fatalf("Was expecting signature error for altered trailing bits, got %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package idx
+
+import (
+	"iter"
+	"slices"
+	"time"
+)
+
+// Dedupe returns the distinct IDs of ids in first-seen order, e.g. to clean user-provided lists before
+// issuing IN queries. ids is left unchanged.
+func Dedupe(ids []ID) []ID {
+	return DedupeInPlace(append([]ID(nil), ids...))
+}
+
+// DedupeInPlace is Dedupe reusing the storage of ids, which it overwrites. The returned slice shares it.
+func DedupeInPlace(ids []ID) []ID {
+	if len(ids) <= dedupeLinearMax {
+		// Short lists are faster to scan than to hash, and do not allocate.
+		n := 0
+	next:
+		for _, id := range ids {
+			for _, seen := range ids[:n] {
+				if seen == id {
+					continue next
+				}
+			}
+			ids[n] = id
+			n++
+		}
+		return ids[:n]
+	}
+	seen := make(map[ID]struct{}, len(ids))
+	n := 0
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids[n] = id
+		n++
+	}
+	return ids[:n]
+}
+
+// dedupeLinearMax is the longest list DedupeInPlace deduplicates without a map.
+const dedupeLinearMax = 32
+
+// MinOf returns the smallest ID of ids, or NilID when ids is empty.
+func MinOf(ids []ID) ID {
+	lo, _ := Bounds(ids)
+	return lo
+}
+
+// MaxOf returns the largest ID of ids, or NilID when ids is empty.
+func MaxOf(ids []ID) ID {
+	_, hi := Bounds(ids)
+	return hi
+}
+
+// Bounds returns the smallest and largest IDs of ids, e.g. to compute the scan range of a batch. Both are
+// NilID when ids is empty.
+func Bounds(ids []ID) (ID, ID) {
+	if len(ids) == 0 {
+		return NilID, NilID
+	}
+	lo, hi := ids[0], ids[0]
+	for _, id := range ids[1:] {
+		if id.Compare(lo) < 0 {
+			lo = id
+		} else if id.Compare(hi) > 0 {
+			hi = id
+		}
+	}
+	return lo, hi
+}
+
+// GroupByBucket groups ids by the start of the d-long time bucket holding their embedded timestamp, as
+// computed by time.Time.Truncate in UTC, e.g. per hour for batch routing or histograms. IDs keep their
+// input order within a bucket. With a d of zero or less every millisecond is its own bucket.
+func GroupByBucket(ids []ID, d time.Duration) map[time.Time][]ID {
+	groups := make(map[time.Time][]ID)
+	for _, id := range ids {
+		bucket := id.Time().Truncate(d)
+		groups[bucket] = append(groups[bucket], id)
+	}
+	return groups
+}
+
+// Diff compares two collections of IDs, e.g. the desired and actual entities of a reconciliation job, and
+// returns the IDs only in new as added and the IDs only in old as removed, both sorted and without
+// duplicates. Copies of the inputs are sorted with SortIDs and merged, so neither is modified.
+func Diff(old, new []ID) (added, removed []ID) {
+	o, n := slices.Clone(old), slices.Clone(new)
+	SortIDs(o)
+	SortIDs(n)
+	o, n = slices.Compact(o), slices.Compact(n)
+	i, j := 0, 0
+	for i < len(o) && j < len(n) {
+		switch c := o[i].Compare(n[j]); {
+		case c < 0:
+			removed = append(removed, o[i])
+			i++
+		case c > 0:
+			added = append(added, n[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return append(added, n[j:]...), append(removed, o[i:]...)
+}
+
+// Chunk splits ids into consecutive chunks of size IDs, the last one holding the remainder, e.g. to stay
+// under the IN clause limit of a database. The chunks share the storage of ids, with their capacity
+// clipped so appending to one does not overwrite the next. Chunk panics if size is less than 1.
+func Chunk(ids []ID, size int) [][]ID {
+	if size < 1 {
+		panic("idx: chunk size must be positive")
+	}
+	chunks := make([][]ID, 0, (len(ids)+size-1)/size)
+	return slices.AppendSeq(chunks, Chunks(ids, size))
+}
+
+// Chunks is the iterator variant of Chunk.
+func Chunks(ids []ID, size int) iter.Seq[[]ID] {
+	if size < 1 {
+		panic("idx: chunk size must be positive")
+	}
+	return slices.Chunk(ids, size)
+}
+
+// NewestK returns the k greatest IDs of ids, hence the most recent ones, newest first. It keeps a bounded
+// heap of k IDs instead of sorting ids, which is left unchanged. Duplicates are not removed.
+func NewestK(ids []ID, k int) []ID {
+	return topK(ids, k, ID.Compare)
+}
+
+// OldestK returns the k smallest IDs of ids, oldest first. See NewestK.
+func OldestK(ids []ID, k int) []ID {
+	return topK(ids, k, func(a, b ID) int {
+		return b.Compare(a)
+	})
+}
+
+// topK returns the k greatest IDs of ids according to cmp, in decreasing order. The root of the heap is
+// the smallest kept ID, replaced by any greater one.
+func topK(ids []ID, k int, cmp func(a, b ID) int) []ID {
+	if k <= 0 {
+		return nil
+	}
+	k = min(k, len(ids))
+	h := slices.Clone(ids[:k])
+	for i := k/2 - 1; i >= 0; i-- {
+		siftDown(h, i, cmp)
+	}
+	for _, id := range ids[k:] {
+		if cmp(id, h[0]) > 0 {
+			h[0] = id
+			siftDown(h, 0, cmp)
+		}
+	}
+	slices.SortFunc(h, func(a, b ID) int {
+		return cmp(b, a)
+	})
+	return h
+}
+
+func siftDown(h []ID, i int, cmp func(a, b ID) int) {
+	for {
+		smallest, l, r := i, 2*i+1, 2*i+2
+		if l < len(h) && cmp(h[l], h[smallest]) < 0 {
+			smallest = l
+		}
+		if r < len(h) && cmp(h[r], h[smallest]) < 0 {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		h[i], h[smallest] = h[smallest], h[i]
+		i = smallest
+	}
+}
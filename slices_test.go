@@ -0,0 +1,172 @@
+package idx
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDedupe(t *testing.T) {
+	a, b, c := NewID(), NewID(), NewID()
+	ids := []ID{b, a, b, c, a, b}
+	original := slices.Clone(ids)
+	if result := Dedupe(ids); !slices.Equal(result, []ID{b, a, c}) {
+		t.Fatalf("Deduplicated IDs %v did not match with %v", result, []ID{b, a, c})
+	}
+	if !slices.Equal(ids, original) {
+		t.Fatalf("Dedupe modified its input")
+	}
+	if result := DedupeInPlace(ids); !slices.Equal(result, []ID{b, a, c}) || &result[0] != &ids[0] {
+		t.Fatalf("Deduplicated IDs %v did not match with %v", result, []ID{b, a, c})
+	}
+	if result := Dedupe(nil); len(result) != 0 {
+		t.Fatalf("Was expecting no IDs, got %v", result)
+	}
+
+	long := make([]ID, 0, 3*dedupeLinearMax)
+	distinct := make([]ID, dedupeLinearMax*2)
+	for i := range distinct {
+		distinct[i] = NewID()
+	}
+	long = append(append(long, distinct...), distinct[:dedupeLinearMax]...)
+	if result := DedupeInPlace(long); !slices.Equal(result, distinct) {
+		t.Fatalf("Deduplicated IDs did not match for long lists")
+	}
+}
+
+func TestDedupeInPlace_Allocations(t *testing.T) {
+	ids := []ID{NewID(), NewID(), NewID()}
+	ids = append(ids, ids...)
+	if allocs := testing.AllocsPerRun(10, func() { DedupeInPlace(ids) }); allocs != 0 {
+		t.Fatalf("Was expecting no allocations, got %v", allocs)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	ids := randomIDs(100)
+	sorted := slices.Clone(ids)
+	SortIDs(sorted)
+	if lo, hi := Bounds(ids); lo != sorted[0] || hi != sorted[len(sorted)-1] {
+		t.Fatalf("Bounds %s, %s did not match with %s, %s", lo.String(), hi.String(), sorted[0].String(), sorted[len(sorted)-1].String())
+	}
+	if MinOf(ids) != sorted[0] || MaxOf(ids) != sorted[len(sorted)-1] {
+		t.Fatalf("MinOf and MaxOf did not match with the sorted IDs")
+	}
+	if lo, hi := Bounds(ids[:1]); lo != ids[0] || hi != ids[0] {
+		t.Fatalf("Bounds of a single ID should be that ID")
+	}
+	if lo, hi := Bounds(nil); lo != NilID || hi != NilID || MinOf(nil) != NilID || MaxOf(nil) != NilID {
+		t.Fatalf("Was expecting NilID for empty input")
+	}
+}
+
+func TestGroupByBucket(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	ids := []ID{
+		MinIDAt(start.Add(5 * time.Minute)),
+		MinIDAt(start.Add(70 * time.Minute)),
+		MinIDAt(start),
+		MaxIDAt(start.Add(time.Hour - time.Millisecond)),
+	}
+	groups := GroupByBucket(ids, time.Hour)
+	if len(groups) != 2 {
+		t.Fatalf("Was expecting 2 buckets, got %d", len(groups))
+	}
+	if group := groups[start]; !slices.Equal(group, []ID{ids[0], ids[2], ids[3]}) {
+		t.Fatalf("Unexpected first bucket %v", group)
+	}
+	if group := groups[start.Add(time.Hour)]; !slices.Equal(group, ids[1:2]) {
+		t.Fatalf("Unexpected second bucket %v", group)
+	}
+	if groups = GroupByBucket(ids, 0); len(groups) != 4 {
+		t.Fatalf("Was expecting a bucket per millisecond, got %d", len(groups))
+	}
+	if groups = GroupByBucket(nil, time.Hour); len(groups) != 0 {
+		t.Fatalf("Was expecting no buckets, got %d", len(groups))
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ids := randomIDs(6)
+	sorted := slices.Clone(ids)
+	SortIDs(sorted)
+	old := []ID{sorted[4], sorted[0], sorted[1], sorted[2], sorted[0]}
+	current := []ID{sorted[5], sorted[2], sorted[3], sorted[1], sorted[3]}
+	added, removed := Diff(old, current)
+	if !slices.Equal(added, []ID{sorted[3], sorted[5]}) || !slices.Equal(removed, []ID{sorted[0], sorted[4]}) {
+		t.Fatalf("Unexpected diff, added %v and removed %v", added, removed)
+	}
+	if old[0] != sorted[4] || current[0] != sorted[5] {
+		t.Fatalf("Diff modified its inputs")
+	}
+	if added, removed = Diff(nil, ids); len(added) != len(ids) || len(removed) != 0 {
+		t.Fatalf("Was expecting every ID to be added, got %v and %v", added, removed)
+	}
+	if added, removed = Diff(ids, ids); len(added) != 0 || len(removed) != 0 {
+		t.Fatalf("Was expecting no difference, got %v and %v", added, removed)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	ids := randomIDs(7)
+	chunks := Chunk(ids, 3)
+	if len(chunks) != 3 || len(chunks[0]) != 3 || len(chunks[2]) != 1 || chunks[2][0] != ids[6] {
+		t.Fatalf("Unexpected chunks %v", chunks)
+	}
+	if cap(chunks[0]) != 3 {
+		t.Fatalf("Chunk capacity was not clipped")
+	}
+	if chunks = Chunk(ids, 7); len(chunks) != 1 || len(chunks[0]) != 7 {
+		t.Fatalf("Was expecting a single chunk, got %v", chunks)
+	}
+	if chunks = Chunk(nil, 3); len(chunks) != 0 {
+		t.Fatalf("Was expecting no chunks, got %v", chunks)
+	}
+	n := 0
+	for chunk := range Chunks(ids, 2) {
+		n += len(chunk)
+	}
+	if n != len(ids) {
+		t.Fatalf("Chunks returned %d IDs, was expecting %d", n, len(ids))
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Was expecting a panic for a zero size")
+		}
+	}()
+	Chunk(ids, 0)
+}
+
+func TestNewestK(t *testing.T) {
+	ids := randomIDs(1000)
+	sorted := slices.Clone(ids)
+	SortIDs(sorted)
+	original := slices.Clone(ids)
+
+	newest := NewestK(ids, 10)
+	expected := slices.Clone(sorted[990:])
+	slices.Reverse(expected)
+	if !slices.Equal(newest, expected) {
+		t.Fatalf("Newest IDs %v did not match with %v", newest, expected)
+	}
+	if oldest := OldestK(ids, 10); !slices.Equal(oldest, sorted[:10]) {
+		t.Fatalf("Oldest IDs %v did not match with %v", oldest, sorted[:10])
+	}
+	if !slices.Equal(ids, original) {
+		t.Fatalf("Input slice was modified")
+	}
+	if oldest := OldestK(ids, 2000); !slices.Equal(oldest, sorted) {
+		t.Fatalf("Was expecting every ID sorted when k exceeds the length")
+	}
+	if NewestK(ids, 0) != nil || len(NewestK(nil, 5)) != 0 {
+		t.Fatalf("Was expecting no IDs")
+	}
+}
+
+func BenchmarkNewestK(b *testing.B) {
+	ids := randomIDs(1_000_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewestK(ids, 100)
+	}
+}
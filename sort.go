@@ -0,0 +1,44 @@
+package idx
+
+import (
+	"slices"
+)
+
+// radixThreshold is the length below which SortIDs uses a comparison sort.
+const radixThreshold = 256
+
+// SortIDs sorts ids in increasing order with an LSD radix sort over the 16 bytes of the IDs, which is
+// several times faster than comparison sorts on multi-million element slices. Byte positions holding the
+// same value in every ID, such as the high bytes of timestamps, are skipped. It allocates a buffer as
+// large as ids; short slices are sorted in place with slices.SortFunc.
+func SortIDs(ids []ID) {
+	if len(ids) < radixThreshold {
+		slices.SortFunc(ids, ID.Compare)
+		return
+	}
+	src, dst := ids, make([]ID, len(ids))
+	var counts [256]int
+	for pos := len(ID{}) - 1; pos >= 0; pos-- {
+		counts = [256]int{}
+		for i := range src {
+			counts[src[i][pos]]++
+		}
+		if counts[src[0][pos]] == len(src) {
+			continue
+		}
+		offset := 0
+		for b, c := range counts {
+			counts[b] = offset
+			offset += c
+		}
+		for i := range src {
+			b := src[i][pos]
+			dst[counts[b]] = src[i]
+			counts[b]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &ids[0] {
+		copy(ids, src)
+	}
+}
@@ -0,0 +1,74 @@
+package idx
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSortIDs(t *testing.T) {
+	for _, n := range []int{0, 1, 10, radixThreshold, 10000} {
+		ids := randomIDs(n)
+		expected := slices.Clone(ids)
+		slices.SortFunc(expected, ID.Compare)
+		SortIDs(ids)
+		if !slices.Equal(ids, expected) {
+			t.Fatalf("Radix sorted IDs did not match with the comparison sort for %d IDs", n)
+		}
+	}
+
+	// IDs sharing bytes skip passes, which must still leave the result in ids.
+	ids := make([]ID, 1000)
+	for i := range ids {
+		ids[i][15] = byte(rand.IntN(256))
+	}
+	SortIDs(ids)
+	if !slices.IsSortedFunc(ids, ID.Compare) {
+		t.Fatalf("IDs differing in a single byte were not sorted")
+	}
+}
+
+// randomIDs returns n IDs spread over a day, in random order.
+func randomIDs(n int) []ID {
+	start := time.Now().Add(-24 * time.Hour)
+	ids := make([]ID, n)
+	for i := range ids {
+		ids[i] = MinIDAt(start.Add(time.Duration(rand.Int64N(int64(24 * time.Hour)))))
+		for j := 6; j < len(ids[i]); j++ {
+			ids[i][j] = byte(rand.IntN(256))
+		}
+	}
+	return ids
+}
+
+func BenchmarkSortIDs(b *testing.B) {
+	ids := randomIDs(1_000_000)
+	buf := make([]ID, len(ids))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(buf, ids)
+		SortIDs(buf)
+	}
+}
+
+func BenchmarkSortIDs_SortSlice(b *testing.B) {
+	ids := randomIDs(1_000_000)
+	buf := make([]ID, len(ids))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(buf, ids)
+		sort.Slice(buf, func(i, j int) bool { return buf[i].Compare(buf[j]) < 0 })
+	}
+}
+
+func BenchmarkSortIDs_SlicesSortFunc(b *testing.B) {
+	ids := randomIDs(1_000_000)
+	buf := make([]ID, len(ids))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(buf, ids)
+		slices.SortFunc(buf, ID.Compare)
+	}
+}
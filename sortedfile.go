@@ -0,0 +1,118 @@
+package idx
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"sort"
+)
+
+// WriteSortedFile writes ids, which must be sorted in ascending order, to a file at path that can be
+// opened with OpenSortedFile. The file uses the WriteIDs format.
+func WriteSortedFile(path string, ids []ID) (err error) {
+	for i := 1; i < len(ids); i++ {
+		if ids[i].Compare(ids[i-1]) < 0 {
+			return ErrUnsorted
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return WriteIDs(f, ids)
+}
+
+// SortedFile gives read-only access to a file written by WriteSortedFile. On Unix systems the file is
+// memory-mapped, so only the pages touched by lookups are loaded; elsewhere it is read into memory.
+// A SortedFile is safe for concurrent use until it is closed.
+type SortedFile struct {
+	data  []byte
+	count int
+	close func() error
+}
+
+// OpenSortedFile opens the file at path. The order of the IDs is not verified.
+func OpenSortedFile(path string) (*SortedFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	if size < int64(streamHeaderSize) {
+		return nil, ErrStreamHeader
+	}
+	data, unmap, err := mapFile(f, int(size))
+	if err != nil {
+		return nil, err
+	}
+	if string(data[:len(streamMagic)]) != streamMagic || data[len(streamMagic)] != streamVersion {
+		_ = unmap()
+		return nil, ErrStreamHeader
+	}
+	count := binary.BigEndian.Uint64(data[len(streamMagic)+1 : streamHeaderSize])
+	body := size - int64(streamHeaderSize)
+	if body%int64(len(NilID)) != 0 || count != uint64(body)/uint64(len(NilID)) {
+		_ = unmap()
+		return nil, io.ErrUnexpectedEOF
+	}
+	return &SortedFile{data: data[streamHeaderSize:], count: int(count), close: unmap}, nil
+}
+
+// Len returns the number of IDs in the file.
+func (f *SortedFile) Len() int {
+	return f.count
+}
+
+// At returns the ID at index i.
+func (f *SortedFile) At(i int) ID {
+	return ID(f.data[i*len(NilID) : (i+1)*len(NilID)])
+}
+
+// Search returns the index of the first ID not less than id, and whether that ID equals id.
+func (f *SortedFile) Search(id ID) (int, bool) {
+	i := sort.Search(f.count, func(i int) bool {
+		return f.At(i).Compare(id) >= 0
+	})
+	return i, i < f.count && f.At(i) == id
+}
+
+// Contains reports whether id is in the file.
+func (f *SortedFile) Contains(id ID) bool {
+	_, ok := f.Search(id)
+	return ok
+}
+
+// Range returns an iterator over the IDs in [from, to), in ascending order.
+func (f *SortedFile) Range(from, to ID) iter.Seq[ID] {
+	return func(yield func(ID) bool) {
+		i, _ := f.Search(from)
+		for ; i < f.count; i++ {
+			id := f.At(i)
+			if id.Compare(to) >= 0 || !yield(id) {
+				return
+			}
+		}
+	}
+}
+
+// Close releases the file mapping. IDs returned earlier remain valid, since they are copies.
+func (f *SortedFile) Close() error {
+	if f.close == nil {
+		return errors.New("idx: sorted file already closed")
+	}
+	err := f.close()
+	f.data, f.count, f.close = nil, 0, nil
+	return err
+}
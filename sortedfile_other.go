@@ -0,0 +1,19 @@
+//go:build !unix
+
+package idx
+
+import (
+	"io"
+	"os"
+)
+
+// mapFile reads the first size bytes of f into memory, for platforms without mmap support.
+func mapFile(f *os.File, size int) ([]byte, func() error, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, nil, err
+	}
+	return data, func() error {
+		return nil
+	}, nil
+}
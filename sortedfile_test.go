@@ -0,0 +1,79 @@
+package idx
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSortedFile(t *testing.T) {
+	ids := make([]ID, 1000)
+	for i := range ids {
+		ids[i] = NewID()
+	}
+	path := filepath.Join(t.TempDir(), "ids.bin")
+	if err := WriteSortedFile(path, ids); err != nil {
+		t.Fatalf("Got error while writing file %v", err)
+	}
+	f, err := OpenSortedFile(path)
+	if err != nil {
+		t.Fatalf("Got error while opening file %v", err)
+	}
+	defer f.Close()
+	if f.Len() != len(ids) {
+		t.Fatalf("File holds %d IDs, was expecting %d", f.Len(), len(ids))
+	}
+	for i, id := range ids {
+		if f.At(i) != id {
+			t.Fatalf("Original ID (%s) did not match with file ID (%s)", id.String(), f.At(i).String())
+		}
+		if j, ok := f.Search(id); !ok || j != i {
+			t.Fatalf("Search for %s returned %d, %v", id.String(), j, ok)
+		}
+	}
+	if f.Contains(NilID) {
+		t.Fatalf("File should not contain NilID")
+	}
+	if i, ok := f.Search(NilID); ok || i != 0 {
+		t.Fatalf("Search for NilID returned %d, %v", i, ok)
+	}
+	result := slices.Collect(f.Range(ids[10], ids[20]))
+	if !slices.Equal(result, ids[10:20]) {
+		t.Fatalf("Range returned %d IDs, was expecting 10", len(result))
+	}
+	if err = f.Close(); err != nil {
+		t.Fatalf("Got error while closing file %v", err)
+	}
+	if err = f.Close(); err == nil {
+		t.Fatalf("Was expecting error on second close")
+	}
+}
+
+func TestOpenSortedFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteSortedFile(filepath.Join(dir, "unsorted.bin"), []ID{NewID(), NilID}); !errors.Is(err, ErrUnsorted) {
+		t.Fatalf("Was expecting unsorted error, got %v", err)
+	}
+	path := filepath.Join(dir, "ids.bin")
+	if err := WriteSortedFile(path, []ID{NilID, NewID()}); err != nil {
+		t.Fatalf("Got error while writing file %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	invalid := map[string]error{
+		"short.bin":     ErrStreamHeader,
+		"magic.bin":     ErrStreamHeader,
+		"truncated.bin": io.ErrUnexpectedEOF,
+	}
+	_ = os.WriteFile(filepath.Join(dir, "short.bin"), data[:4], 0o600)
+	_ = os.WriteFile(filepath.Join(dir, "magic.bin"), append([]byte("XYZ"), data[3:]...), 0o600)
+	_ = os.WriteFile(filepath.Join(dir, "truncated.bin"), data[:len(data)-1], 0o600)
+	for name, expected := range invalid {
+		_, err := OpenSortedFile(filepath.Join(dir, name))
+		if !errors.Is(err, expected) {
+			t.Fatalf("Error for %s did not match expectation %v : %v", name, err, expected)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package idx
+
+import (
+	"time"
+)
+
+// SQLRange returns a predicate selecting rows whose ID column was generated in [from, to), with its
+// arguments, for database/sql, sqlx and GORM raw queries alike:
+//
+//	where, args := idx.SQLRange("id", from, to)
+//	rows, err := db.Query("SELECT * FROM orders WHERE "+where, args...)
+//
+// The bounds are IDs, so they are bound in the representation selected with ConfigureValue; all of
+// them sort like the IDs. Bounds have millisecond precision. Times before 1970, such as the zero
+// time.Time, bind the zero ID rather than NULL: as from they leave the range unbounded below, as to
+// they match no rows. PostgreSQL users rewrite the ? placeholders or use sqlx.Rebind.
+func SQLRange(column string, from, to time.Time) (string, []interface{}) {
+	return column + " >= ? AND " + column + " < ?", []interface{}{RangeBound(MinIDAt(from)), RangeBound(MinIDAt(to))}
+}
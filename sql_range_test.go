@@ -0,0 +1,56 @@
+package idx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	_ "github.com/mattn/go-sqlite3"
+	"testing"
+	"time"
+)
+
+func TestSQLRange(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	clause, args := SQLRange("orders.id", from, to)
+	if clause != "orders.id >= ? AND orders.id < ?" {
+		t.Fatalf("Unexpected clause %q", clause)
+	}
+	if len(args) != 2 || args[0] != RangeBound(MinIDAt(from)) || args[1] != RangeBound(MinIDAt(to)) {
+		t.Fatalf("Arguments %v did not match with the boundary IDs", args)
+	}
+
+	defer ConfigureValue()
+	ConfigureValue(WithValueFormat(ValueText))
+	value, err := args[1].(driver.Valuer).Value()
+	if err != nil || value != MinIDAt(to).String() {
+		t.Fatalf("Was expecting the ULID string bound, got %v: %v", value, err)
+	}
+
+	// A time before 1970 binds the zero ID instead of NULL, which would match no rows.
+	_, args = SQLRange("orders.id", time.Time{}, to)
+	if value, err = args[0].(driver.Valuer).Value(); err != nil || value != NilID.String() {
+		t.Fatalf("Was expecting the zero ID bound, got %v: %v", value, err)
+	}
+}
+
+func TestSQLRange_SQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Got error while opening database %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	if _, err = db.Exec("CREATE TABLE orders (id BLOB PRIMARY KEY)"); err != nil {
+		t.Fatalf("Got error while creating table %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err = db.Exec("INSERT INTO orders (id) VALUES (?)", NewID()); err != nil {
+			t.Fatalf("Got error while inserting row %v", err)
+		}
+	}
+	where, args := SQLRange("id", time.Time{}, time.Now().Add(time.Hour))
+	var n int
+	if err = db.QueryRow("SELECT COUNT(*) FROM orders WHERE "+where, args...).Scan(&n); err != nil || n != 3 {
+		t.Fatalf("Was expecting 3 rows since the zero time, got %d: %v", n, err)
+	}
+}
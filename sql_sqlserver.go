@@ -0,0 +1,102 @@
+package idx
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+)
+
+// SQLServerID is an ID stored in a SQL Server uniqueidentifier column. SQL Server sorts GUIDs by their
+// stored bytes 10-15 first, then 8-9, 6-7, 4-5 and 0-3. SQLServerID arranges the ID bytes so that this
+// order is the ULID order: the 48-bit timestamp lands in the last group, which keeps inserts into a
+// clustered index sequential, and IDs of the same millisecond follow their entropy. As a consequence the
+// GUID SQL Server displays differs from UUIDString.
+type SQLServerID ID
+
+// ID returns the SQLServerID as an ID.
+func (id SQLServerID) ID() ID {
+	return ID(id)
+}
+
+func (id SQLServerID) String() string {
+	return ID(id).String()
+}
+
+// MarshalText returns the ULID string of the SQLServerID. See https://pkg.go.dev/encoding#TextMarshaler
+func (id SQLServerID) MarshalText() ([]byte, error) {
+	return ID(id).MarshalText()
+}
+
+// UnmarshalText populates the SQLServerID from a ULID string. See https://pkg.go.dev/encoding#TextUnmarshaler
+func (id *SQLServerID) UnmarshalText(b []byte) error {
+	return (*ID)(id).UnmarshalText(b)
+}
+
+func (id SQLServerID) MarshalJSON() ([]byte, error) {
+	return ID(id).MarshalJSON()
+}
+
+func (id *SQLServerID) UnmarshalJSON(b []byte) error {
+	return (*ID)(id).UnmarshalJSON(b)
+}
+
+// Value returns the uniqueidentifier bytes in the order SQL Server stores them, or nil for NilID.
+// See https://pkg.go.dev/database/sql/driver#Valuer
+func (id SQLServerID) Value() (driver.Value, error) {
+	if ID(id) == NilID {
+		return nil, nil
+	}
+	b := ToSQLServerGUID(ID(id))
+	return b[:], nil
+}
+
+// Scan populates the SQLServerID from the 16 uniqueidentifier bytes returned by the driver. NULL is
+// scanned as NilID. See https://pkg.go.dev/database/sql#Scanner
+func (id *SQLServerID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*id = SQLServerID(NilID)
+	case []byte:
+		parsed, err := FromSQLServerGUID(v)
+		if err != nil {
+			return err
+		}
+		*id = SQLServerID(parsed)
+	default:
+		return fmt.Errorf("idx: cannot scan %T into SQLServerID", src)
+	}
+	return nil
+}
+
+// GormDataType declares SQLServerIDs as binary data to GORM.
+func (SQLServerID) GormDataType() string {
+	return "bytes"
+}
+
+// ToSQLServerGUID returns the uniqueidentifier bytes, in SQL Server storage order, that SQLServerID writes
+// for id.
+func ToSQLServerGUID(id ID) [16]byte {
+	// SQL Server compares the stored bytes in the order 10-15, 8-9, 6-7, 4-5, 0-3, as SqlGuid does, each
+	// byte read in its stored position, so the ID bytes are placed in that order.
+	return [16]byte{
+		id[12], id[13], id[14], id[15],
+		id[10], id[11],
+		id[8], id[9],
+		id[6], id[7],
+		id[0], id[1], id[2], id[3], id[4], id[5],
+	}
+}
+
+// FromSQLServerGUID reverses ToSQLServerGUID.
+func FromSQLServerGUID(b []byte) (ID, error) {
+	if len(b) != len(NilID) {
+		return NilID, ulid.ErrDataSize
+	}
+	return ID{
+		b[10], b[11], b[12], b[13], b[14], b[15],
+		b[8], b[9],
+		b[6], b[7],
+		b[4], b[5],
+		b[0], b[1], b[2], b[3],
+	}, nil
+}
@@ -0,0 +1,79 @@
+package idx
+
+import (
+	"bytes"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"slices"
+	"testing"
+)
+
+// compareSQLServerGUID compares uniqueidentifier bytes in storage order the way SQL Server does, with the
+// byte order of System.Data.SqlTypes.SqlGuid.
+func compareSQLServerGUID(a, b [16]byte) int {
+	for _, i := range []int{10, 11, 12, 13, 14, 15, 8, 9, 6, 7, 4, 5, 0, 1, 2, 3} {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func TestToSQLServerGUID(t *testing.T) {
+	ids := make([]ID, 1000)
+	for i := range ids {
+		ids[i] = NewID()
+	}
+	ids = append(ids, NilID, MaxIDAt(ids[0].Time()), MinIDAt(ids[999].Time()))
+	guids := make([][16]byte, len(ids))
+	for i, id := range ids {
+		guids[i] = ToSQLServerGUID(id)
+		decoded, err := FromSQLServerGUID(guids[i][:])
+		if err != nil || decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s): %v", id.String(), decoded.String(), err)
+		}
+	}
+	slices.SortFunc(ids, ID.Compare)
+	slices.SortFunc(guids, compareSQLServerGUID)
+	for i, id := range ids {
+		if guid := ToSQLServerGUID(id); guid != guids[i] {
+			t.Fatalf("SQL Server order differs from ID order at %d", i)
+		}
+	}
+	id := ID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	expected := [16]byte{12, 13, 14, 15, 10, 11, 8, 9, 6, 7, 0, 1, 2, 3, 4, 5}
+	if guid := ToSQLServerGUID(id); guid != expected {
+		t.Fatalf("GUID %x did not match with %x", guid, expected)
+	}
+	if _, err := FromSQLServerGUID(nil); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
+
+func TestSQLServerID_Scan(t *testing.T) {
+	id := NewID()
+	value, err := SQLServerID(id).Value()
+	if err != nil {
+		t.Fatalf("Got error while getting value %v", err)
+	}
+	guid := ToSQLServerGUID(id)
+	if !bytes.Equal(value.([]byte), guid[:]) {
+		t.Fatalf("Value %x did not match with %x", value, guid)
+	}
+	var result SQLServerID
+	if err = result.Scan(value); err != nil || result.ID() != id {
+		t.Fatalf("Original ID (%s) did not match with scanned ID (%s): %v", id.String(), result.String(), err)
+	}
+	if err = result.Scan(nil); err != nil || result.ID() != NilID {
+		t.Fatalf("Was expecting NilID for NULL, got %s: %v", result.String(), err)
+	}
+	if value, err = SQLServerID(NilID).Value(); err != nil || value != nil {
+		t.Fatalf("Was expecting nil value for NilID, got %v: %v", value, err)
+	}
+	if err = result.Scan(id.String()); err == nil {
+		t.Fatalf("Was expecting error for string value")
+	}
+}
@@ -0,0 +1,108 @@
+package idx
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+)
+
+// SwappedID is an ID stored in the layout of MySQL 8's UUID_TO_BIN(uuid, 1), which moves the fourth group
+// of the UUID form (bytes 6-7) to the front and the first group (bytes 0-3) after the second. Columns
+// written by SQL such as
+//
+//	INSERT INTO orders (id) VALUES (UUID_TO_BIN(?, 1))
+//
+// with the UUIDString of an ID scan back to that ID, and BIN_TO_UUID(id, 1) displays its UUID form. The
+// swap is meant for version 1 UUIDs: for ULIDs it places entropy bytes first, so prefer ID for new
+// columns where index locality matters.
+type SwappedID ID
+
+// ID returns the SwappedID as an ID.
+func (id SwappedID) ID() ID {
+	return ID(id)
+}
+
+func (id SwappedID) String() string {
+	return ID(id).String()
+}
+
+// MarshalText returns the ULID string of the SwappedID. See https://pkg.go.dev/encoding#TextMarshaler
+func (id SwappedID) MarshalText() ([]byte, error) {
+	return ID(id).MarshalText()
+}
+
+// UnmarshalText populates the SwappedID from a ULID string. See https://pkg.go.dev/encoding#TextUnmarshaler
+func (id *SwappedID) UnmarshalText(b []byte) error {
+	return (*ID)(id).UnmarshalText(b)
+}
+
+func (id SwappedID) MarshalJSON() ([]byte, error) {
+	return ID(id).MarshalJSON()
+}
+
+func (id *SwappedID) UnmarshalJSON(b []byte) error {
+	return (*ID)(id).UnmarshalJSON(b)
+}
+
+// Value returns the swapped bytes, or nil for NilID. See https://pkg.go.dev/database/sql/driver#Valuer
+func (id SwappedID) Value() (driver.Value, error) {
+	if ID(id) == NilID {
+		return nil, nil
+	}
+	b := SwapUUIDBytes(ID(id))
+	return b[:], nil
+}
+
+// Scan populates the SwappedID from 16 swapped bytes. NULL is scanned as NilID.
+// See https://pkg.go.dev/database/sql#Scanner
+func (id *SwappedID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*id = SwappedID(NilID)
+	case []byte:
+		parsed, err := FromSwappedUUIDBytes(v)
+		if err != nil {
+			return err
+		}
+		*id = SwappedID(parsed)
+	default:
+		return fmt.Errorf("idx: cannot scan %T into SwappedID", src)
+	}
+	return nil
+}
+
+// GormDataType declares SwappedIDs as binary data to GORM.
+func (SwappedID) GormDataType() string {
+	return "bytes"
+}
+
+// SwapUUIDBytes returns the bytes MySQL's UUID_TO_BIN(uuid, 1) produces for the UUIDString of id.
+func SwapUUIDBytes(id ID) [16]byte {
+	var b [16]byte
+	copy(b[0:2], id[6:8])
+	copy(b[2:4], id[4:6])
+	copy(b[4:8], id[0:4])
+	copy(b[8:], id[8:])
+	return b
+}
+
+// FromSwappedUUIDBytes reverses SwapUUIDBytes, like MySQL's BIN_TO_UUID(b, 1).
+func FromSwappedUUIDBytes(b []byte) (ID, error) {
+	if len(b) != len(NilID) {
+		return NilID, ulid.ErrDataSize
+	}
+	var id ID
+	copy(id[0:4], b[4:8])
+	copy(id[4:6], b[2:4])
+	copy(id[6:8], b[0:2])
+	copy(id[8:], b[8:])
+	return id, nil
+}
+
+// VerifySwapped reports whether stored holds id in the UUID_TO_BIN(uuid, 1) layout, to check rows written
+// by SQL functions against the IDs the application expects.
+func VerifySwapped(stored []byte, id ID) bool {
+	swapped := SwapUUIDBytes(id)
+	return bytes.Equal(stored, swapped[:])
+}
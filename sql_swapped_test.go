@@ -0,0 +1,57 @@
+package idx
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestSwapUUIDBytes(t *testing.T) {
+	// Example from the MySQL reference manual for UUID_TO_BIN.
+	id, err := FromUUIDString("6ccd780c-baba-1026-9564-5b8c656024db")
+	if err != nil {
+		t.Fatalf("Got error while parsing UUID %v", err)
+	}
+	swapped := SwapUUIDBytes(id)
+	if result := strings.ToUpper(hex.EncodeToString(swapped[:])); result != "1026BABA6CCD780C95645B8C656024DB" {
+		t.Fatalf("Swapped bytes %s did not match with UUID_TO_BIN output", result)
+	}
+	if !VerifySwapped(swapped[:], id) || VerifySwapped(id[:], id) {
+		t.Fatalf("Verification did not match the swapped layout")
+	}
+	decoded, err := FromSwappedUUIDBytes(swapped[:])
+	if err != nil || decoded != id {
+		t.Fatalf("Original ID (%s) did not match with decoded ID (%s): %v", id.String(), decoded.String(), err)
+	}
+	if _, err = FromSwappedUUIDBytes(swapped[:15]); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+}
+
+func TestSwappedID_Scan(t *testing.T) {
+	id := NewID()
+	value, err := SwappedID(id).Value()
+	if err != nil {
+		t.Fatalf("Got error while getting value %v", err)
+	}
+	swapped := SwapUUIDBytes(id)
+	if !bytes.Equal(value.([]byte), swapped[:]) {
+		t.Fatalf("Value %x did not match with %x", value, swapped)
+	}
+	var result SwappedID
+	if err = result.Scan(value); err != nil || result.ID() != id {
+		t.Fatalf("Original ID (%s) did not match with scanned ID (%s): %v", id.String(), result.String(), err)
+	}
+	if err = result.Scan(nil); err != nil || result.ID() != NilID {
+		t.Fatalf("Was expecting NilID for NULL, got %s: %v", result.String(), err)
+	}
+	if value, err = SwappedID(NilID).Value(); err != nil || value != nil {
+		t.Fatalf("Was expecting nil value for NilID, got %v: %v", value, err)
+	}
+	if err = result.Scan(id.String()); err == nil {
+		t.Fatalf("Was expecting error for string value")
+	}
+}
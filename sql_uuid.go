@@ -0,0 +1,72 @@
+package idx
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// UUID is an ID stored in SQL databases in the canonical UUID form instead of 16 raw bytes, so that
+// PostgreSQL uuid columns, with their native operators and display, can be used. The bytes are unchanged,
+// so values still sort by creation time. In JSON and text it is the ULID string, like ID.
+type UUID ID
+
+// ID returns the UUID as an ID.
+func (id UUID) ID() ID {
+	return ID(id)
+}
+
+func (id UUID) String() string {
+	return ID(id).String()
+}
+
+// MarshalText returns the ULID string of the UUID. See https://pkg.go.dev/encoding#TextMarshaler
+func (id UUID) MarshalText() ([]byte, error) {
+	return ID(id).MarshalText()
+}
+
+// UnmarshalText populates the UUID from a ULID string. See https://pkg.go.dev/encoding#TextUnmarshaler
+func (id *UUID) UnmarshalText(b []byte) error {
+	return (*ID)(id).UnmarshalText(b)
+}
+
+func (id UUID) MarshalJSON() ([]byte, error) {
+	return ID(id).MarshalJSON()
+}
+
+func (id *UUID) UnmarshalJSON(b []byte) error {
+	return (*ID)(id).UnmarshalJSON(b)
+}
+
+// Value returns the canonical UUID string, or nil for NilID. See https://pkg.go.dev/database/sql/driver#Valuer
+func (id UUID) Value() (driver.Value, error) {
+	if ID(id) == NilID {
+		return nil, nil
+	}
+	return ID(id).UUIDString(), nil
+}
+
+// Scan populates the UUID from the string or byte forms drivers return for uuid columns: the canonical
+// and hexadecimal strings or 16 raw bytes. NULL is scanned as NilID. See https://pkg.go.dev/database/sql#Scanner
+func (id *UUID) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case nil:
+		*id = UUID(NilID)
+	case string:
+		*(*ID)(id), err = FromHex(v)
+	case []byte:
+		if len(v) == len(id) {
+			*id = UUID(v)
+		} else {
+			*(*ID)(id), err = FromHex(string(v))
+		}
+	default:
+		err = fmt.Errorf("idx: cannot scan %T into UUID", src)
+	}
+	return err
+}
+
+// GormDataType declares UUIDs as strings to GORM.
+func (UUID) GormDataType() string {
+	return "string"
+}
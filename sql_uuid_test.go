@@ -0,0 +1,60 @@
+package idx
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"testing"
+)
+
+func TestUUID_Value(t *testing.T) {
+	id := NewID()
+	value, err := UUID(id).Value()
+	if err != nil {
+		t.Fatalf("Got error while getting value %v", err)
+	}
+	if value != id.UUIDString() {
+		t.Fatalf("Value %v did not match with %s", value, id.UUIDString())
+	}
+	if value, err = UUID(NilID).Value(); err != nil || value != nil {
+		t.Fatalf("Was expecting nil value for NilID, got %v: %v", value, err)
+	}
+}
+
+func TestUUID_Scan(t *testing.T) {
+	id := NewID()
+	for _, src := range []interface{}{id.UUIDString(), []byte(id.UUIDString()), id.Hex(), id[:]} {
+		var result UUID
+		if err := result.Scan(src); err != nil {
+			t.Fatalf("Got error while scanning %v: %v", src, err)
+		}
+		if result.ID() != id {
+			t.Fatalf("Original ID (%s) did not match with scanned ID (%s)", id.String(), result.String())
+		}
+	}
+	result := UUID(id)
+	if err := result.Scan(nil); err != nil || result.ID() != NilID {
+		t.Fatalf("Was expecting NilID for NULL, got %s: %v", result.String(), err)
+	}
+	if err := result.Scan(id.String()); !errors.Is(err, ulid.ErrDataSize) {
+		t.Fatalf("Was expecting data size error, got %v", err)
+	}
+	if err := result.Scan(42); err == nil {
+		t.Fatalf("Was expecting error for unsupported type")
+	}
+}
+
+func TestUUID_MarshalJSON(t *testing.T) {
+	id := UUID(NewID())
+	b, err := json.Marshal(id)
+	if err != nil {
+		t.Fatalf("Got error while marshaling %v", err)
+	}
+	if string(b) != `"`+id.String()+`"` {
+		t.Fatalf("Marshaled value %s is not the ULID string", b)
+	}
+	var result UUID
+	if err = json.Unmarshal(b, &result); err != nil || result != id {
+		t.Fatalf("Unmarshaled ID (%s) did not match with %s: %v", result.String(), id.String(), err)
+	}
+}
@@ -0,0 +1,49 @@
+package idx
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// Randomize implements the randomize.Randomizer interface (https://pkg.go.dev/github.com/volatiletech/randomize#Randomizer)
+// used by the tests sqlboiler generates. The timestamp is the current time and the entropy comes from
+// nextInt, so successive values are unique.
+//
+// Binary ID columns are mapped to ID with a type replacement in sqlboiler.toml, using NullID for nullable
+// columns:
+//
+//	[[types]]
+//	  [types.match]
+//	    db_type = "binary"
+//	    nullable = false
+//	  [types.replace]
+//	    type = "idx.ID"
+//	  [types.imports]
+//	    third_party = ['"github.com/ieshan/idx"']
+//
+//	[[types]]
+//	  [types.match]
+//	    db_type = "binary"
+//	    nullable = true
+//	  [types.replace]
+//	    type = "idx.NullID"
+//	  [types.imports]
+//	    third_party = ['"github.com/ieshan/idx"']
+//
+// Use db_type = "bytea" for PostgreSQL.
+func (id *ID) Randomize(nextInt func() int64, _ string, _ bool) {
+	*id = MinIDAt(time.Now())
+	binary.BigEndian.PutUint16(id[6:], uint16(nextInt()))
+	binary.BigEndian.PutUint64(id[8:], uint64(nextInt()))
+}
+
+// Randomize implements the randomize.Randomizer interface, leaving the NullID invalid when shouldBeNull
+// is set.
+func (n *NullID) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		*n = NullID{}
+		return
+	}
+	n.ID.Randomize(nextInt, fieldType, false)
+	n.Valid = true
+}
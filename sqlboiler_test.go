@@ -0,0 +1,39 @@
+package idx
+
+import (
+	"testing"
+	"time"
+)
+
+func counter() func() int64 {
+	var n int64
+	return func() int64 {
+		n++
+		return n
+	}
+}
+
+func TestID_Randomize(t *testing.T) {
+	nextInt := counter()
+	var a, b ID
+	a.Randomize(nextInt, "binary", false)
+	b.Randomize(nextInt, "binary", false)
+	if a == b || a == NilID {
+		t.Fatalf("Randomized IDs %s and %s are not unique", a.String(), b.String())
+	}
+	if since := time.Since(a.Time()); since < 0 || since > time.Minute {
+		t.Fatalf("Randomized ID time %v is not close to now", a.Time())
+	}
+}
+
+func TestNullID_Randomize(t *testing.T) {
+	var n NullID
+	n.Randomize(counter(), "binary", true)
+	if n.Valid {
+		t.Fatalf("Was expecting invalid NullID")
+	}
+	n.Randomize(counter(), "binary", false)
+	if !n.Valid || n.ID == NilID {
+		t.Fatalf("Was expecting valid NullID, got %+v", n)
+	}
+}
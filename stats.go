@@ -0,0 +1,48 @@
+package idx
+
+import (
+	"slices"
+	"time"
+)
+
+// EstimateRate estimates how many IDs per second were generated from a sample of ids, using their embedded
+// timestamps, e.g. for capacity planning or abuse detection. window is the time covered by the sample, from
+// the oldest timestamp to the end of the millisecond of the newest one, so a sample created within a single
+// millisecond covers one millisecond. The sample order does not matter. Both results are zero for an empty
+// sample.
+func EstimateRate(ids []ID) (perSecond float64, window time.Duration) {
+	if len(ids) == 0 {
+		return 0, 0
+	}
+	lo, hi := timestamp(ids[0]), timestamp(ids[0])
+	for _, id := range ids[1:] {
+		ts := timestamp(id)
+		lo, hi = min(lo, ts), max(hi, ts)
+	}
+	window = time.Duration(hi-lo+1) * time.Millisecond
+	return float64(len(ids)) / window.Seconds(), window
+}
+
+// Bucket is the number of IDs created in the time bucket starting at Start.
+type Bucket struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+// Histogram counts ids per bucket-long time bucket of their embedded timestamp, with buckets computed as in
+// GroupByBucket, e.g. to plot the traffic shape of a list of record IDs. Buckets are sorted by Start and
+// empty buckets are omitted.
+func Histogram(ids []ID, bucket time.Duration) []Bucket {
+	counts := make(map[time.Time]int)
+	for _, id := range ids {
+		counts[id.Time().Truncate(bucket)]++
+	}
+	buckets := make([]Bucket, 0, len(counts))
+	for start, count := range counts {
+		buckets = append(buckets, Bucket{Start: start, Count: count})
+	}
+	slices.SortFunc(buckets, func(a, b Bucket) int {
+		return a.Start.Compare(b.Start)
+	})
+	return buckets
+}
@@ -0,0 +1,52 @@
+package idx
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEstimateRate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]ID, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		// 1000 IDs over 10 seconds, in reverse order.
+		ids = append(ids, MinIDAt(start.Add(time.Duration(999-i)*10*time.Millisecond)))
+	}
+	rate, window := EstimateRate(ids)
+	if window != 9991*time.Millisecond {
+		t.Fatalf("Was expecting a window of 9.991s, got %s", window)
+	}
+	if math.Abs(rate-1000/9.991) > 1e-9 {
+		t.Fatalf("Was expecting about 100 IDs per second, got %f", rate)
+	}
+
+	if rate, window = EstimateRate([]ID{NewID()}); window != time.Millisecond || rate != 1000 {
+		t.Fatalf("Was expecting 1000 IDs per second over 1ms, got %f over %s", rate, window)
+	}
+	if rate, window = EstimateRate(nil); rate != 0 || window != 0 {
+		t.Fatalf("Was expecting zero rate for an empty sample, got %f over %s", rate, window)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{3 * time.Hour, 10 * time.Minute, 0, 3*time.Hour + time.Second, 59 * time.Minute}
+	ids := make([]ID, 0, len(offsets))
+	for _, offset := range offsets {
+		ids = append(ids, MinIDAt(start.Add(offset)))
+	}
+	expected := []Bucket{{Start: start, Count: 3}, {Start: start.Add(3 * time.Hour), Count: 2}}
+	buckets := Histogram(ids, time.Hour)
+	if len(buckets) != len(expected) {
+		t.Fatalf("Buckets %v did not match with %v", buckets, expected)
+	}
+	for i := range expected {
+		if !buckets[i].Start.Equal(expected[i].Start) || buckets[i].Count != expected[i].Count {
+			t.Fatalf("Buckets %v did not match with %v", buckets, expected)
+		}
+	}
+	if buckets = Histogram(nil, time.Hour); len(buckets) != 0 {
+		t.Fatalf("Was expecting no buckets, got %v", buckets)
+	}
+}
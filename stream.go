@@ -0,0 +1,88 @@
+package idx
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// LineError reports an invalid value read by a StreamDecoder.
+type LineError struct {
+	// Line is the one-based line number of the value.
+	Line int
+	// Value is the line with surrounding whitespace removed.
+	Value string
+	Err   error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("idx: line %d: %q: %v", e.Line, e.Value, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// StreamDecoder reads one ID per line without buffering the whole input. Each line holds either a JSON
+// string, as in newline-delimited JSON, or a bare ULID string. Blank lines are skipped and JSON null lines
+// decode to NilID.
+type StreamDecoder struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewStreamDecoder returns a StreamDecoder reading from r.
+func NewStreamDecoder(r io.Reader) *StreamDecoder {
+	return &StreamDecoder{scanner: bufio.NewScanner(r)}
+}
+
+// Decode returns the next ID. Invalid values are reported as a *LineError, after which decoding can
+// continue with the next line. io.EOF is returned once the input is exhausted.
+func (d *StreamDecoder) Decode() (ID, error) {
+	for d.scanner.Scan() {
+		d.line++
+		b := bytes.TrimSpace(d.scanner.Bytes())
+		if len(b) == 0 {
+			continue
+		}
+		var id ID
+		var err error
+		if b[0] == '"' || bytes.Equal(b, []byte("null")) {
+			err = id.UnmarshalJSON(b)
+		} else {
+			err = decodeText(b, &id)
+		}
+		if err != nil {
+			return NilID, &LineError{Line: d.line, Value: string(b), Err: err}
+		}
+		return id, nil
+	}
+	if err := d.scanner.Err(); err != nil {
+		return NilID, err
+	}
+	return NilID, io.EOF
+}
+
+// All returns an iterator over the remaining IDs. Invalid values are yielded with a *LineError and the
+// iteration goes on, so callers decide whether to stop; read errors are yielded once and end the
+// iteration.
+func (d *StreamDecoder) All() iter.Seq2[ID, error] {
+	return func(yield func(ID, error) bool) {
+		for {
+			id, err := d.Decode()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(id, err) {
+				return
+			}
+			var lineErr *LineError
+			if err != nil && !errors.As(err, &lineErr) {
+				return
+			}
+		}
+	}
+}
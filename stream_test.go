@@ -0,0 +1,66 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"io"
+	"strings"
+	"testing"
+)
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
+
+func TestStreamDecoder_Decode(t *testing.T) {
+	ids := []ID{NewID(), NewID(), NewID()}
+	input := "\"" + ids[0].String() + "\"\n\n  " + strings.ToLower(ids[1].String()) + "\r\nnull\n" + ids[2].String()
+	d := NewStreamDecoder(strings.NewReader(input))
+	for _, expected := range []ID{ids[0], ids[1], NilID, ids[2]} {
+		id, err := d.Decode()
+		if err != nil {
+			t.Fatalf("Got error while decoding stream %v", err)
+		}
+		if id != expected {
+			t.Fatalf("Decoded ID (%s) did not match with %s", id.String(), expected.String())
+		}
+	}
+	if _, err := d.Decode(); err != io.EOF {
+		t.Fatalf("Was expecting EOF, got %v", err)
+	}
+}
+
+func TestStreamDecoder_All(t *testing.T) {
+	id := NewID()
+	input := id.String() + "\ninvalid\n\"" + id.String() + "\"\n"
+	var result []ID
+	var lineErr *LineError
+	for decoded, err := range NewStreamDecoder(strings.NewReader(input)).All() {
+		if err != nil {
+			if !errors.As(err, &lineErr) || !errors.Is(err, ulid.ErrDataSize) {
+				t.Fatalf("Was expecting line error, got %v", err)
+			}
+			continue
+		}
+		result = append(result, decoded)
+	}
+	if len(result) != 2 || result[0] != id || result[1] != id {
+		t.Fatalf("Decoded IDs %v did not match with %s", result, id.String())
+	}
+	if lineErr == nil || lineErr.Line != 2 || lineErr.Value != "invalid" {
+		t.Fatalf("Unexpected line error %v", lineErr)
+	}
+
+	count := 0
+	for _, err := range NewStreamDecoder(errReader{}).All() {
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("Was expecting read error, got %v", err)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Fatalf("Read error was yielded %d times", count)
+	}
+}
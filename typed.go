@@ -0,0 +1,115 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+)
+
+// maxPrefixLen is the longest prefix allowed by the TypeID specification.
+const maxPrefixLen = 63
+
+// ErrPrefix is returned when a prefix is malformed or does not match the expected type.
+var ErrPrefix = errors.New("idx: invalid prefix")
+
+// PrefixedID is an ID qualified with a type prefix, rendered following the TypeID convention as
+// "<prefix>_<lower-case ulid>", e.g. user_01hak8jpf7s0sfmj2x96w37wxb. An empty prefix renders the bare ULID.
+type PrefixedID struct {
+	Prefix string
+	ID     ID
+}
+
+// ParsePrefixed parses a TypeID-style string into its prefix and ID.
+func ParsePrefixed(val string) (PrefixedID, error) {
+	var p PrefixedID
+	return p, p.UnmarshalText([]byte(val))
+}
+
+func (p PrefixedID) String() string {
+	b := make([]byte, 0, len(p.Prefix)+1+ulid.EncodedSize)
+	if p.Prefix != "" {
+		b = append(b, p.Prefix...)
+		b = append(b, '_')
+	}
+	return string(LowercaseEncoding.AppendEncode(b, p.ID))
+}
+
+// MarshalText returns the TypeID-style string. See https://pkg.go.dev/encoding#TextMarshaler
+func (p PrefixedID) MarshalText() ([]byte, error) {
+	if err := validatePrefix(p.Prefix); err != nil {
+		return nil, err
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText parses a TypeID-style string. See https://pkg.go.dev/encoding#TextUnmarshaler
+func (p *PrefixedID) UnmarshalText(b []byte) error {
+	val := string(b)
+	prefix, suffix := "", val
+	if i := strings.LastIndexByte(val, '_'); i >= 0 {
+		prefix, suffix = val[:i], val[i+1:]
+		if prefix == "" {
+			return ErrPrefix
+		}
+	}
+	if err := validatePrefix(prefix); err != nil {
+		return err
+	}
+	id, err := FromString(suffix)
+	if err != nil {
+		return err
+	}
+	p.Prefix, p.ID = prefix, id
+	return nil
+}
+
+// TypedEncoder renders and parses IDs carrying one fixed type prefix.
+type TypedEncoder struct {
+	prefix string
+}
+
+// NewTypedEncoder returns a TypedEncoder for prefix. A prefix consists of at most 63 lower-case ASCII letters
+// and underscores, and must neither start nor end with an underscore. ErrPrefix is returned otherwise.
+func NewTypedEncoder(prefix string) (*TypedEncoder, error) {
+	if err := validatePrefix(prefix); err != nil {
+		return nil, err
+	}
+	return &TypedEncoder{prefix: prefix}, nil
+}
+
+// Prefix returns the type prefix of the encoder.
+func (e *TypedEncoder) Prefix() string {
+	return e.prefix
+}
+
+// Encode returns the TypeID-style string of id.
+func (e *TypedEncoder) Encode(id ID) string {
+	return PrefixedID{Prefix: e.prefix, ID: id}.String()
+}
+
+// Decode parses val, returning ErrPrefix when it does not carry the encoder's prefix.
+func (e *TypedEncoder) Decode(val string) (ID, error) {
+	p, err := ParsePrefixed(val)
+	if err != nil {
+		return NilID, err
+	}
+	if p.Prefix != e.prefix {
+		return NilID, ErrPrefix
+	}
+	return p.ID, nil
+}
+
+func validatePrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if len(prefix) > maxPrefixLen || prefix[0] == '_' || prefix[len(prefix)-1] == '_' {
+		return ErrPrefix
+	}
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; (c < 'a' || c > 'z') && c != '_' {
+			return ErrPrefix
+		}
+	}
+	return nil
+}
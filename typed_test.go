@@ -0,0 +1,83 @@
+package idx
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestTypedEncoder(t *testing.T) {
+	enc, err := NewTypedEncoder("user")
+	if err != nil {
+		t.Fatalf("Got error while creating encoder %v", err)
+	}
+	id := NewID()
+	encoded := enc.Encode(id)
+	if encoded != "user_"+strings.ToLower(id.String()) {
+		t.Fatalf("Unexpected encoded value %s for %s", encoded, id.String())
+	}
+	for _, val := range []string{encoded, "user_" + id.String()} {
+		decoded, err := enc.Decode(val)
+		if err != nil {
+			t.Fatalf("Got error while decoding %s: %v", val, err)
+		}
+		if decoded != id {
+			t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+		}
+	}
+
+	invalid := map[string]error{
+		"org_" + strings.ToLower(id.String()):  ErrPrefix,
+		strings.ToLower(id.String()):           ErrPrefix,
+		"_" + strings.ToLower(id.String()):     ErrPrefix,
+		"user_01hak8jpf7s0sfmj2x96w37wxi":      ulid.ErrInvalidCharacters,
+		"user_":                                ulid.ErrDataSize,
+		"User_" + strings.ToLower(id.String()): ErrPrefix,
+	}
+	for val, expected := range invalid {
+		if _, err := enc.Decode(val); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+
+	for _, prefix := range []string{"_user", "user_", "User", "user1", strings.Repeat("a", 64)} {
+		if _, err := NewTypedEncoder(prefix); !errors.Is(err, ErrPrefix) {
+			t.Fatalf("Was expecting prefix error for %q, got %v", prefix, err)
+		}
+	}
+	if _, err := NewTypedEncoder("user_group"); err != nil {
+		t.Fatalf("Got error while creating encoder with underscore %v", err)
+	}
+}
+
+func TestPrefixedID(t *testing.T) {
+	type IdTestStruct struct {
+		ID PrefixedID `json:"id"`
+	}
+	id := NewID()
+	data := IdTestStruct{ID: PrefixedID{Prefix: "user_group", ID: id}}
+	b, err := json.Marshal(&data)
+	if err != nil {
+		t.Fatalf("Got error while marshaling to JSON %v", err)
+	}
+	if string(b) != `{"id":"user_group_`+strings.ToLower(id.String())+`"}` {
+		t.Fatalf("Unexpected JSON %s", string(b))
+	}
+	var result IdTestStruct
+	if err = json.Unmarshal(b, &result); err != nil {
+		t.Fatalf("Got error while unmarshaling JSON %v", err)
+	}
+	if result != data {
+		t.Fatalf("Decoded value %+v did not match with original value %+v", result, data)
+	}
+
+	bare, err := ParsePrefixed(id.String())
+	if err != nil || bare.Prefix != "" || bare.ID != id || bare.String() != strings.ToLower(id.String()) {
+		t.Fatalf("Unexpected value for bare ULID %+v %v", bare, err)
+	}
+	if _, err = json.Marshal(PrefixedID{Prefix: "Bad", ID: id}); !errors.Is(err, ErrPrefix) {
+		t.Fatalf("Was expecting prefix error, got %v", err)
+	}
+}
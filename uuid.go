@@ -0,0 +1,39 @@
+package idx
+
+import (
+	"encoding/hex"
+)
+
+// UUIDEncodedSize is the length of the canonical UUID form of an ID.
+const UUIDEncodedSize = dashedHexEncodedSize
+
+// UUIDString returns the ID bytes in the canonical 8-4-4-4-12 lower-case UUID form. The bytes are not
+// modified, so the version and variant bits are those of the ULID.
+func (id ID) UUIDString() string {
+	var dst [UUIDEncodedSize]byte
+	return string(appendUUID(dst[:0], id))
+}
+
+// FromUUIDString parses the canonical 8-4-4-4-12 UUID form, in either case, into an ID with the same bytes.
+func FromUUIDString(val string) (ID, error) {
+	var id ID
+	if err := decodeDashedHex(val, &id); err != nil {
+		return NilID, err
+	}
+	return id, nil
+}
+
+// appendUUID appends the 8-4-4-4-12 hexadecimal form of the ID bytes to b.
+func appendUUID(b []byte, id ID) []byte {
+	var buf [36]byte
+	hex.Encode(buf[0:8], id[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], id[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], id[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], id[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:36], id[10:16])
+	return append(b, buf[:]...)
+}
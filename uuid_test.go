@@ -0,0 +1,41 @@
+package idx
+
+import (
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"strings"
+	"testing"
+)
+
+func TestID_UUIDString(t *testing.T) {
+	id := ID{0x01, 0x8A, 0xA6, 0x89, 0x5E, 0xE7, 0xC8, 0x32, 0xF9, 0xA2, 0x5D, 0x2B, 0x78, 0x33, 0xF3, 0xAB}
+	if id.UUIDString() != "018aa689-5ee7-c832-f9a2-5d2b7833f3ab" {
+		t.Fatalf("Unexpected UUID value %s", id.UUIDString())
+	}
+	for i := 0; i < 100; i++ {
+		id = NewID()
+		for _, val := range []string{id.UUIDString(), strings.ToUpper(id.UUIDString())} {
+			decoded, err := FromUUIDString(val)
+			if err != nil {
+				t.Fatalf("Got error while decoding %s: %v", val, err)
+			}
+			if decoded != id {
+				t.Fatalf("Original ID (%s) did not match with decoded ID (%s)", id.String(), decoded.String())
+			}
+		}
+	}
+}
+
+func TestFromUUIDString(t *testing.T) {
+	invalid := map[string]error{
+		"":                                     ulid.ErrDataSize,
+		"018aa6895ee7c832f9a25d2b7833f3ab":     ulid.ErrDataSize,
+		"018aa689-5ee7-c832-f9a2-5d2b7833f3ag": ulid.ErrInvalidCharacters,
+		"018aa689_5ee7_c832_f9a2_5d2b7833f3ab": ulid.ErrInvalidCharacters,
+	}
+	for val, expected := range invalid {
+		if _, err := FromUUIDString(val); !errors.Is(err, expected) {
+			t.Fatalf("Error for %q did not match expectation %v : %v", val, err, expected)
+		}
+	}
+}
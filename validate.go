@@ -0,0 +1,111 @@
+package idx
+
+import (
+	"errors"
+	"fmt"
+	"github.com/oklog/ulid/v2"
+	"strings"
+)
+
+// ErrValidation is returned by ValidateAll when at least one value is not a valid ID.
+var ErrValidation = errors.New("idx: validation failed")
+
+// ValidationStatus classifies a single value checked by ValidateAll.
+type ValidationStatus uint8
+
+const (
+	// StatusValid marks a valid ID string.
+	StatusValid ValidationStatus = iota
+	// StatusWrongLength marks a value that is not 26 characters long.
+	StatusWrongLength
+	// StatusBadAlphabet marks a value containing characters outside the Crockford base32 alphabet.
+	StatusBadAlphabet
+	// StatusOverflow marks a value whose first character makes it exceed 128 bits.
+	StatusOverflow
+)
+
+func (s ValidationStatus) String() string {
+	switch s {
+	case StatusValid:
+		return "valid"
+	case StatusWrongLength:
+		return "wrong length"
+	case StatusBadAlphabet:
+		return "bad alphabet"
+	case StatusOverflow:
+		return "overflow"
+	}
+	return fmt.Sprintf("ValidationStatus(%d)", uint8(s))
+}
+
+// ValidationReport is the result of ValidateAll.
+type ValidationReport struct {
+	// Values holds the validated inputs.
+	Values []string
+	// Statuses holds the classification of each input, in input order.
+	Statuses    []ValidationStatus
+	Valid       int
+	WrongLength int
+	BadAlphabet int
+	Overflow    int
+}
+
+// Invalid returns the number of rejected values.
+func (r ValidationReport) Invalid() int {
+	return r.WrongLength + r.BadAlphabet + r.Overflow
+}
+
+// String returns a human-readable summary followed by one line per rejected value.
+func (r ValidationReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d values: %d valid, %d wrong length, %d bad alphabet, %d overflow",
+		len(r.Statuses), r.Valid, r.WrongLength, r.BadAlphabet, r.Overflow)
+	for i, status := range r.Statuses {
+		if status != StatusValid {
+			fmt.Fprintf(&sb, "\n%d: %q: %s", i, r.Values[i], status)
+		}
+	}
+	return sb.String()
+}
+
+// ValidateAll classifies every value in a single pass and summarizes the outcome. The report is always
+// returned; the error wraps ErrValidation when at least one value is invalid.
+func ValidateAll(vals []string) (ValidationReport, error) {
+	report := ValidationReport{
+		Values:   vals,
+		Statuses: make([]ValidationStatus, len(vals)),
+	}
+	for i, val := range vals {
+		status := classify(val)
+		report.Statuses[i] = status
+		switch status {
+		case StatusValid:
+			report.Valid++
+		case StatusWrongLength:
+			report.WrongLength++
+		case StatusBadAlphabet:
+			report.BadAlphabet++
+		case StatusOverflow:
+			report.Overflow++
+		}
+	}
+	if n := report.Invalid(); n > 0 {
+		return report, fmt.Errorf("%w: %d of %d values are invalid", ErrValidation, n, len(vals))
+	}
+	return report, nil
+}
+
+func classify(val string) ValidationStatus {
+	if len(val) != ulid.EncodedSize {
+		return StatusWrongLength
+	}
+	for i := 0; i < len(val); i++ {
+		if dec[val[i]] == 0xFF {
+			return StatusBadAlphabet
+		}
+	}
+	if val[0] > '7' {
+		return StatusOverflow
+	}
+	return StatusValid
+}
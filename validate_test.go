@@ -0,0 +1,34 @@
+package idx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAll(t *testing.T) {
+	id := NewID()
+	vals := []string{id.String(), "wrong", "01HAJ2Q3T69IJMMBDNAMVZ3FQB", "81HAK8JPF7S0SFMJ2X96W37WXB", strings.ToLower(id.String())}
+	report, err := ValidateAll(vals)
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("Was expecting validation error, got %v", err)
+	}
+	expected := []ValidationStatus{StatusValid, StatusWrongLength, StatusBadAlphabet, StatusOverflow, StatusValid}
+	for i, status := range expected {
+		if report.Statuses[i] != status {
+			t.Fatalf("Status of %q did not match expectation %s : %s", vals[i], report.Statuses[i], status)
+		}
+	}
+	if report.Valid != 2 || report.WrongLength != 1 || report.BadAlphabet != 1 || report.Overflow != 1 || report.Invalid() != 3 {
+		t.Fatalf("Unexpected counts in report %+v", report)
+	}
+	summary := report.String()
+	if !strings.HasPrefix(summary, "5 values: 2 valid, 1 wrong length, 1 bad alphabet, 1 overflow") ||
+		!strings.Contains(summary, `1: "wrong": wrong length`) {
+		t.Fatalf("Unexpected report summary %s", summary)
+	}
+
+	if _, err = ValidateAll([]string{id.String()}); err != nil {
+		t.Fatalf("Was expecting no error, got %v", err)
+	}
+}
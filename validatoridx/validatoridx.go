@@ -0,0 +1,62 @@
+// Package validatoridx registers idx aware validation tags with go-playground/validator.
+package validatoridx
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/ieshan/idx"
+	"reflect"
+)
+
+const (
+	// Tag accepts idx.ID fields and strings or byte slices holding a valid ID.
+	Tag = "idxid"
+	// RequiredTag is like Tag, but additionally rejects NilID.
+	RequiredTag = "idxid_required"
+)
+
+// RegisterValidations adds the idxid and idxid_required tags to v.
+func RegisterValidations(v *validator.Validate) error {
+	if err := v.RegisterValidation(Tag, validateID); err != nil {
+		return err
+	}
+	return v.RegisterValidation(RequiredTag, validateRequiredID, true)
+}
+
+func validateID(fl validator.FieldLevel) bool {
+	_, ok := fieldID(fl.Field())
+	return ok
+}
+
+func validateRequiredID(fl validator.FieldLevel) bool {
+	id, ok := fieldID(fl.Field())
+	return ok && !id.IsZero()
+}
+
+// fieldID extracts an ID from an ID, string or byte slice field, reporting whether the field holds a valid ID.
+func fieldID(field reflect.Value) (idx.ID, bool) {
+	for field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		if field.IsNil() {
+			return idx.NilID, false
+		}
+		field = field.Elem()
+	}
+	if field.Type() == reflect.TypeOf(idx.NilID) {
+		return field.Interface().(idx.ID), true
+	}
+	switch field.Kind() {
+	case reflect.String:
+		id, err := idx.FromString(field.String())
+		return id, err == nil
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.Uint8 {
+			return idx.NilID, false
+		}
+		b := field.Bytes()
+		if len(b) == len(idx.NilID) {
+			return idx.ID(b), true
+		}
+		id, err := idx.FromString(string(b))
+		return id, err == nil
+	}
+	return idx.NilID, false
+}
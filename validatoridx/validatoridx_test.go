@@ -0,0 +1,46 @@
+package validatoridx
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/ieshan/idx"
+	"testing"
+)
+
+func TestRegisterValidations(t *testing.T) {
+	type Request struct {
+		ID       idx.ID  `validate:"idxid_required"`
+		ParentID idx.ID  `validate:"idxid"`
+		OwnerID  string  `validate:"idxid_required"`
+		GroupID  string  `validate:"omitempty,idxid"`
+		LinkID   *idx.ID `validate:"omitempty,idxid_required"`
+	}
+	v := validator.New()
+	if err := RegisterValidations(v); err != nil {
+		t.Fatalf("Got error while registering validations %v", err)
+	}
+
+	id := idx.NewID()
+	valid := []Request{
+		{ID: id, OwnerID: id.String()},
+		{ID: id, ParentID: id, OwnerID: id.String(), GroupID: id.String(), LinkID: &id},
+	}
+	for index, req := range valid {
+		if err := v.Struct(req); err != nil {
+			t.Fatalf("Expecting request %d to be valid, got %v", index, err)
+		}
+	}
+
+	nilID := idx.NilID
+	invalid := []Request{
+		{OwnerID: id.String()},
+		{ID: id},
+		{ID: id, OwnerID: "01HAJ2Q3T69IJMMBDNAMVZ3FQB"},
+		{ID: id, OwnerID: id.String(), GroupID: "wrong"},
+		{ID: id, OwnerID: id.String(), LinkID: &nilID},
+	}
+	for index, req := range invalid {
+		if err := v.Struct(req); err == nil {
+			t.Fatalf("Expecting request %d to be invalid, but it's valid", index)
+		}
+	}
+}
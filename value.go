@@ -0,0 +1,134 @@
+package idx
+
+import (
+	"database/sql/driver"
+	"errors"
+	"github.com/oklog/ulid/v2"
+	"sync/atomic"
+)
+
+// ErrScanFormat is returned by Scan for values in a format excluded with WithScanFormats.
+var ErrScanFormat = errors.New("idx: scanned value format is not accepted")
+
+// ValueFormat is the representation ID.Value returns.
+type ValueFormat int
+
+const (
+	// ValueBinary returns the 16 ID bytes, which is the default.
+	ValueBinary ValueFormat = iota
+	// ValueText returns the ULID string.
+	ValueText
+	// ValueUUID returns the canonical UUID string.
+	ValueUUID
+)
+
+// ValueOption configures ID.Value, see ConfigureValue.
+type ValueOption func(*valueConfig)
+
+type valueConfig struct {
+	format    ValueFormat
+	nilAsZero bool
+	// scan is the set of formats accepted by Scan, indexed by ValueFormat; zero accepts all of them.
+	scan uint8
+}
+
+var valueSettings atomic.Pointer[valueConfig]
+
+// WithValueFormat makes ID.Value return the given representation.
+func WithValueFormat(format ValueFormat) ValueOption {
+	return func(c *valueConfig) {
+		c.format = format
+	}
+}
+
+// WithNilAsZero makes ID.Value return NilID in the selected representation instead of NULL, for
+// NOT NULL columns.
+func WithNilAsZero() ValueOption {
+	return func(c *valueConfig) {
+		c.nilAsZero = true
+	}
+}
+
+// WithScanFormats makes ID.Scan reject values in formats other than the given ones with ErrScanFormat.
+// The hexadecimal form counts as ValueUUID.
+func WithScanFormats(formats ...ValueFormat) ValueOption {
+	return func(c *valueConfig) {
+		for _, format := range formats {
+			c.scan |= 1 << format
+		}
+	}
+}
+
+// BeginTransition switches the storage format of IDs without downtime: Value writes only the new
+// format while Scan accepts both, so instances running the old and the new configuration can share
+// the database while rows are converted, e.g. with UUIDConversion:
+//
+//	idx.BeginTransition(idx.ValueUUID, idx.ValueBinary)
+//
+// CompleteTransition then stops accepting the old format, and BeginTransition can be called again to
+// roll back. Both can be toggled at runtime; opts are applied on top, e.g. WithNilAsZero.
+func BeginTransition(from, to ValueFormat, opts ...ValueOption) {
+	ConfigureValue(append([]ValueOption{WithValueFormat(to), WithScanFormats(from, to)}, opts...)...)
+}
+
+// CompleteTransition ends a transition started with BeginTransition, so Value writes and Scan accepts
+// only the to format.
+func CompleteTransition(to ValueFormat, opts ...ValueOption) {
+	ConfigureValue(append([]ValueOption{WithValueFormat(to), WithScanFormats(to)}, opts...)...)
+}
+
+// ConfigureValue sets how ID.Value, NullID.Value and IDs.Value represent IDs and which formats ID.Scan
+// accepts, for the whole program. It is meant to be called during start up or when toggling a transition:
+//
+//	idx.ConfigureValue(idx.WithValueFormat(idx.ValueText), idx.WithNilAsZero())
+//
+// Calling it without options restores the default of 16 bytes with NilID as NULL, and of Scan
+// accepting every format. The wrapper types such as UUID keep their own representation.
+func ConfigureValue(opts ...ValueOption) {
+	if len(opts) == 0 {
+		valueSettings.Store(nil)
+		return
+	}
+	var cfg valueConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	valueSettings.Store(&cfg)
+}
+
+func (c *valueConfig) value(id ID) driver.Value {
+	switch c.format {
+	case ValueText:
+		return id.String()
+	case ValueUUID:
+		return id.UUIDString()
+	}
+	b := make([]byte, len(id))
+	copy(b, id[:])
+	return b
+}
+
+func (c *valueConfig) accepts(format ValueFormat) bool {
+	return c.scan == 0 || c.scan&(1<<format) != 0
+}
+
+// textFormat returns the format of a text value of length n, as detected by Scan.
+func textFormat(n int) ValueFormat {
+	if n == ulid.EncodedSize {
+		return ValueText
+	}
+	return ValueUUID
+}
+
+// RangeBound is an ID bound in a range predicate, as used by SQLRange. Unlike ID.Value, NilID is written
+// as the zero ID in the configured representation, since comparing with NULL would match no rows.
+type RangeBound ID
+
+// Value implements the sql/driver.Valuer interface.
+func (b RangeBound) Value() (driver.Value, error) {
+	cfg := valueSettings.Load()
+	if cfg == nil {
+		cfg = &valueConfig{}
+	}
+	return cfg.value(ID(b)), nil
+}
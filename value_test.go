@@ -0,0 +1,84 @@
+package idx
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestConfigureValue(t *testing.T) {
+	defer ConfigureValue()
+	id := NewID()
+
+	ConfigureValue(WithValueFormat(ValueText))
+	if value, err := id.Value(); err != nil || value != id.String() {
+		t.Fatalf("Value %v did not match with %s: %v", value, id.String(), err)
+	}
+	if value, err := NilID.Value(); err != nil || value != nil {
+		t.Fatalf("Was expecting NULL for NilID, got %v: %v", value, err)
+	}
+
+	ConfigureValue(WithValueFormat(ValueUUID), WithNilAsZero())
+	if value, err := id.Value(); err != nil || value != id.UUIDString() {
+		t.Fatalf("Value %v did not match with %s: %v", value, id.UUIDString(), err)
+	}
+	if value, err := NilID.Value(); err != nil || value != NilID.UUIDString() {
+		t.Fatalf("Was expecting zero UUID for NilID, got %v: %v", value, err)
+	}
+	if value, err := NewNullID(id).Value(); err != nil || value != id.UUIDString() {
+		t.Fatalf("Value %v did not match with %s: %v", value, id.UUIDString(), err)
+	}
+
+	ConfigureValue(WithNilAsZero())
+	if value, err := NilID.Value(); err != nil || !bytes.Equal(value.([]byte), NilID[:]) {
+		t.Fatalf("Was expecting zero bytes for NilID, got %v: %v", value, err)
+	}
+
+	ConfigureValue()
+	if value, err := NilID.Value(); err != nil || value != nil {
+		t.Fatalf("Was expecting NULL for NilID, got %v: %v", value, err)
+	}
+	if value, err := id.Value(); err != nil || !bytes.Equal(value.([]byte), id[:]) {
+		t.Fatalf("Value %v did not match with %s: %v", value, id.String(), err)
+	}
+}
+
+func TestBeginTransition(t *testing.T) {
+	defer ConfigureValue()
+	id := NewID()
+
+	BeginTransition(ValueUUID, ValueBinary)
+	if value, err := id.Value(); err != nil || !bytes.Equal(value.([]byte), id[:]) {
+		t.Fatalf("Value %v did not match with %s: %v", value, id.String(), err)
+	}
+	for _, src := range []interface{}{id[:], id.UUIDString(), []byte(id.Hex())} {
+		var result ID
+		if err := result.Scan(src); err != nil || result != id {
+			t.Fatalf("Original ID (%s) did not match with the scanned ID %s: %v", id.String(), result.String(), err)
+		}
+	}
+	var result ID
+	if err := result.Scan(id.String()); !errors.Is(err, ErrScanFormat) {
+		t.Fatalf("Was expecting scan format error, got %v", err)
+	}
+
+	CompleteTransition(ValueBinary)
+	if err := result.Scan(id.UUIDString()); !errors.Is(err, ErrScanFormat) {
+		t.Fatalf("Was expecting scan format error, got %v", err)
+	}
+	if err := result.Scan(id[:]); err != nil || result != id {
+		t.Fatalf("Original ID (%s) did not match with the scanned ID %s: %v", id.String(), result.String(), err)
+	}
+	if err := result.Scan(nil); err != nil || result != NilID {
+		t.Fatalf("Was expecting NilID for NULL, got %s: %v", result.String(), err)
+	}
+
+	// Rolling back accepts the old format again.
+	BeginTransition(ValueBinary, ValueUUID)
+	if value, err := id.Value(); err != nil || value != id.UUIDString() {
+		t.Fatalf("Value %v did not match with %s: %v", value, id.UUIDString(), err)
+	}
+	if err := result.Scan(id[:]); err != nil || result != id {
+		t.Fatalf("Original ID (%s) did not match with the scanned ID %s: %v", id.String(), result.String(), err)
+	}
+}